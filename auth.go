@@ -2,21 +2,38 @@ package deviantart
 
 import (
 	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"os"
+	"time"
 
-	"github.com/dghubble/sling"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/authhandler"
 	"golang.org/x/oauth2/clientcredentials"
 
 	"github.com/leonidboykov/go-deviantart/internal/authserver"
-	"github.com/leonidboykov/go-deviantart/internal/ratelimit"
 )
 
-// CallbackURL defines redirect URL for OAuth2.
-var CallbackURL = "http://localhost:8080/callback"
+const (
+	authURL  = "https://www.deviantart.com/oauth2/authorize"
+	tokenURL = "https://www.deviantart.com/oauth2/token"
+)
+
+// DefaultCallbackURL is the default redirect URL for OAuth2.
+const DefaultCallbackURL = "http://localhost:8080/callback"
 
-// Authenticator describes authentication pipeline.
-type Authenticator func(s *sling.Sling) error
+// CallbackURL defines redirect URL for OAuth2 used by [AuthorizationCode] and
+// [HeadlessAuthorizationCode].
+//
+// Deprecated: Set [AuthorizationCodeConfig.RedirectURL] or
+// [AuthProfile.CallbackURL] instead.
+var CallbackURL = DefaultCallbackURL
+
+// Authenticator describes authentication pipeline. It returns a token source
+// used to authorize requests. The context carries the HTTP client configured
+// for the [Client] under the [oauth2.HTTPClient] key.
+type Authenticator func(ctx context.Context) (oauth2.TokenSource, error)
 
 // ClientCredentials allows gives access to "public" endpoints and do not
 // require user authorization. Use this method to access read-only endpoints.
@@ -24,14 +41,57 @@ func ClientCredentials(clientID, clientSecret string) Authenticator {
 	conf := &clientcredentials.Config{
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
-		TokenURL:     "https://www.deviantart.com/oauth2/token",
+		TokenURL:     tokenURL,
+	}
+	return func(ctx context.Context) (oauth2.TokenSource, error) {
+		return newRefreshTokenSource(nil, func(*oauth2.Token) oauth2.TokenSource {
+			return conf.TokenSource(ctx)
+		}), nil
+	}
+}
+
+// FromTokenSource uses tokens of ts, e.g. obtained by a web application that
+// performs the OAuth2 flow on its own.
+func FromTokenSource(ts oauth2.TokenSource) Authenticator {
+	return func(ctx context.Context) (oauth2.TokenSource, error) {
+		return ts, nil
+	}
+}
+
+// StaticToken uses the access token as is. Requests fail once the token
+// expires.
+func StaticToken(accessToken string) Authenticator {
+	return FromTokenSource(oauth2.StaticTokenSource(&oauth2.Token{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+	}))
+}
+
+// RefreshToken obtains access tokens with the refresh token. New refresh
+// tokens issued on refresh are kept in memory only, use
+// [AuthorizationCodeConfig] with a [TokenStore] holding the token to persist
+// them.
+func RefreshToken(clientID, clientSecret, refreshToken string) Authenticator {
+	conf := &oauth2.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  authURL,
+			TokenURL: tokenURL,
+		},
 	}
-	return func(s *sling.Sling) error {
-		s.Doer(ratelimit.NewHTTPClient(conf.Client(context.Background())))
-		return nil
+	return func(ctx context.Context) (oauth2.TokenSource, error) {
+		return configTokenSource(ctx, conf, &oauth2.Token{RefreshToken: refreshToken}), nil
 	}
 }
 
+// configTokenSource returns a token source that refreshes tok with conf.
+func configTokenSource(ctx context.Context, conf *oauth2.Config, tok *oauth2.Token) *refreshTokenSource {
+	return newRefreshTokenSource(tok, func(tok *oauth2.Token) oauth2.TokenSource {
+		return conf.TokenSource(ctx, tok)
+	})
+}
+
 const (
 	BasicScope       = "basic"
 	BrowseMLTScope   = "browse.mlt"
@@ -62,29 +122,202 @@ var AllScopes = []string{
 
 // AuthorizationCode grant is the most common OAuth2 grant type and gives access
 // to aspects of a users account. Use this method if you need to upload images.
+//
+// Use [AuthorizationCodeConfig] to keep tokens between runs.
 func AuthorizationCode(clientID, clientSecret string, scopes ...string) Authenticator {
-	conf := &oauth2.Config{
+	conf := &AuthorizationCodeConfig{
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
-		Endpoint: oauth2.Endpoint{
-			AuthURL:  "https://www.deviantart.com/oauth2/authorize",
-			TokenURL: "https://www.deviantart.com/oauth2/token",
-		},
-		RedirectURL: CallbackURL,
-		Scopes:      scopes,
-	}
-	return func(s *sling.Sling) error {
-		tok, err := authhandler.TokenSource(
-			context.Background(),
-			conf,
-			"state", // TODO: This is unsecure.
-			authserver.AuthHandler(CallbackURL),
-		).Token()
+		Scopes:       scopes,
+	}
+	return conf.Authenticator()
+}
+
+// HeadlessAuthorizationCode is like [AuthorizationCode] but does not need a
+// browser on the same machine. It prints the authorization URL and reads the
+// redirect URL from stdin.
+func HeadlessAuthorizationCode(clientID, clientSecret string, scopes ...string) Authenticator {
+	conf := &AuthorizationCodeConfig{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scopes:       scopes,
+		Headless:     true,
+	}
+	return conf.Authenticator()
+}
+
+// AuthorizationCodeConfig configures the Authorization Code grant.
+type AuthorizationCodeConfig struct {
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+
+	// RedirectURL defines redirect URL for OAuth2. Default is
+	// [DefaultCallbackURL], unless the deprecated [CallbackURL] is changed.
+	RedirectURL string
+
+	// Endpoint overrides DeviantArt OAuth2 endpoint, e.g. to use a stand-in
+	// authorization server.
+	Endpoint oauth2.Endpoint
+
+	// PKCE enables Proof Key for Code Exchange with S256 challenge method.
+	PKCE bool
+
+	// CallbackTimeout limits the time to wait for the user to authorize the
+	// application. Default is 5 minutes.
+	CallbackTimeout time.Duration
+
+	// SuccessPage and FailurePage are HTML pages served by the local callback
+	// server once the authorization succeeds or fails. Default pages are used
+	// if empty.
+	SuccessPage string
+	FailurePage string
+
+	// Headless replaces the local callback server with a prompt, for machines
+	// where the browser cannot reach the callback. The user visits the
+	// authorization URL elsewhere and pastes the redirect URL.
+	Headless bool
+
+	// AllowBareCode lets the user paste the authorization code alone instead
+	// of the redirect URL in headless mode. The state cannot be validated
+	// without the redirect URL, so the flow is exposed to CSRF: a code of
+	// another account tricked into the prompt would be accepted.
+	AllowBareCode bool
+
+	// Prompt shows the authorization URL and returns the pasted redirect URL
+	// in headless mode. Default prompt uses stdin and stdout.
+	Prompt func(authCodeURL string) (string, error)
+
+	// TokenStore persists tokens between runs. If set, the interactive flow
+	// only runs when the store has no token or the stored token is rejected
+	// as invalid, and refreshed tokens are written back to the store. Other
+	// failures to refresh the stored token, e.g. network errors, are returned
+	// as is.
+	TokenStore TokenStore
+
+	// OnTokenSaveError is called when a token cannot be written to
+	// TokenStore. The token is used anyway, so requests keep working, but it
+	// is lost once the program exits. Such errors are ignored if nil.
+	OnTokenSaveError func(err error)
+}
+
+func (c *AuthorizationCodeConfig) oauth2Config() *oauth2.Config {
+	redirectURL := c.RedirectURL
+	if redirectURL == "" {
+		redirectURL = CallbackURL
+	}
+	endpoint := c.Endpoint
+	if endpoint.AuthURL == "" {
+		endpoint.AuthURL = authURL
+	}
+	if endpoint.TokenURL == "" {
+		endpoint.TokenURL = tokenURL
+	}
+	return &oauth2.Config{
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret,
+		Endpoint:     endpoint,
+		RedirectURL:  redirectURL,
+		Scopes:       c.Scopes,
+	}
+}
+
+// Authenticator returns an [Authenticator] for the configuration.
+func (c *AuthorizationCodeConfig) Authenticator() Authenticator {
+	conf := c.oauth2Config()
+	store := c.TokenStore
+	return func(ctx context.Context) (oauth2.TokenSource, error) {
+		if store != nil {
+			ts, err := c.storedTokenSource(ctx, conf, store)
+			var authErr *AuthError
+			switch {
+			case err == nil:
+				return ts, nil
+			case errors.Is(err, ErrNoToken):
+			case errors.As(err, &authErr) && authErr.Kind == AuthInvalidGrant:
+			default:
+				// Temporary failures must not start the interactive flow.
+				return nil, err
+			}
+		}
+
+		tok, err := c.authorize(ctx, conf, c.authHandler(conf))
 		if err != nil {
-			return nil
+			return nil, newAuthError(err)
 		}
 
-		s.Doer(ratelimit.NewHTTPClient(conf.Client(context.Background(), tok)))
-		return nil
+		var ts oauth2.TokenSource = configTokenSource(ctx, conf, tok)
+		if store != nil {
+			if err := store.Save(tok); err != nil {
+				c.tokenSaveError(err)
+			}
+			ts = newStoreTokenSource(ts, store, tok, c.tokenSaveError)
+		}
+		return &scopedTokenSource{TokenSource: ts, scopes: c.Scopes}, nil
+	}
+}
+
+// storedTokenSource returns a token source for the token kept in the store. It
+// fails with [ErrNoToken] if there is no token and with [AuthError] if the
+// token cannot be refreshed.
+func (c *AuthorizationCodeConfig) storedTokenSource(ctx context.Context, conf *oauth2.Config, store TokenStore) (oauth2.TokenSource, error) {
+	tok, err := store.Load()
+	if errors.Is(err, ErrNoToken) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to load token: %w", err)
+	}
+	// Make sure the stored token is still usable, refreshing it if needed.
+	ts := newStoreTokenSource(configTokenSource(ctx, conf, tok), store, tok, c.tokenSaveError)
+	if _, err := ts.Token(); err != nil {
+		return nil, newAuthError(err)
+	}
+	return &scopedTokenSource{TokenSource: ts, scopes: c.Scopes}, nil
+}
+
+func (c *AuthorizationCodeConfig) tokenSaveError(err error) {
+	if c.OnTokenSaveError != nil {
+		c.OnTokenSaveError(err)
+	}
+}
+
+func (c *AuthorizationCodeConfig) authHandler(conf *oauth2.Config) authhandler.AuthorizationHandler {
+	if !c.Headless {
+		return authserver.AuthHandler(conf.RedirectURL, authserver.Options{
+			Timeout:     c.CallbackTimeout,
+			SuccessPage: c.SuccessPage,
+			FailurePage: c.FailurePage,
+		})
+	}
+	prompt := c.Prompt
+	if prompt == nil {
+		prompt = authserver.StdinPrompt(os.Stdin, os.Stdout, c.AllowBareCode)
+	}
+	return authserver.PromptHandler(prompt, c.AllowBareCode)
+}
+
+// ErrStateMismatch is returned when the state received in the authorization
+// callback differs from the one sent with the authorization request.
+var ErrStateMismatch = errors.New("oauth2 state mismatch")
+
+// authorize obtains a token via the interactive flow using a random state and,
+// if enabled, PKCE.
+func (c *AuthorizationCodeConfig) authorize(ctx context.Context, conf *oauth2.Config, handler authhandler.AuthorizationHandler) (*oauth2.Token, error) {
+	state := authserver.RandString(32)
+	var authOpts, exchangeOpts []oauth2.AuthCodeOption
+	if c.PKCE {
+		verifier := oauth2.GenerateVerifier()
+		authOpts = append(authOpts, oauth2.S256ChallengeOption(verifier))
+		exchangeOpts = append(exchangeOpts, oauth2.VerifierOption(verifier))
+	}
+
+	code, callbackState, err := handler(conf.AuthCodeURL(state, authOpts...))
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(callbackState), []byte(state)) != 1 {
+		return nil, ErrStateMismatch
 	}
+	return conf.Exchange(ctx, code, exchangeOpts...)
 }
@@ -0,0 +1,127 @@
+package deviantart
+
+import (
+	"errors"
+	"net"
+	"net/http"
+
+	"golang.org/x/oauth2"
+
+	"github.com/leonidboykov/go-deviantart/internal/authserver"
+)
+
+// AuthErrorKind classifies authentication failures.
+type AuthErrorKind uint8
+
+const (
+	// AuthFailed is an authentication failure of any other kind.
+	AuthFailed AuthErrorKind = iota
+
+	// AuthDenied means the user denied access to the application.
+	AuthDenied
+
+	// AuthNetwork means the authorization server could not be reached.
+	AuthNetwork
+
+	// AuthInvalidClient means the client ID or secret is invalid.
+	AuthInvalidClient
+
+	// AuthInvalidGrant means the authorization code, refresh token or access
+	// token is invalid, expired or revoked. The user has to authorize the
+	// application again.
+	AuthInvalidGrant
+)
+
+func (k AuthErrorKind) String() string {
+	switch k {
+	case AuthDenied:
+		return "access denied"
+	case AuthNetwork:
+		return "network failure"
+	case AuthInvalidClient:
+		return "invalid client"
+	case AuthInvalidGrant:
+		return "invalid grant"
+	}
+	return "failed"
+}
+
+// AuthError is returned when authentication fails. Use [errors.As] to inspect
+// the kind of the failure.
+type AuthError struct {
+	Kind AuthErrorKind
+	Err  error
+}
+
+func (e *AuthError) Error() string {
+	if e.Kind == AuthFailed {
+		return "authentication failed: " + e.Err.Error()
+	}
+	return "authentication failed: " + e.Kind.String() + ": " + e.Err.Error()
+}
+
+func (e *AuthError) Unwrap() error {
+	return e.Err
+}
+
+// newAuthError wraps err into [AuthError] of the matching kind.
+func newAuthError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var authErr *AuthError
+	if errors.As(err, &authErr) {
+		return err
+	}
+	return &AuthError{Kind: authErrorKind(err), Err: err}
+}
+
+func authErrorKind(err error) AuthErrorKind {
+	var (
+		callbackErr *authserver.CallbackError
+		retrieveErr *oauth2.RetrieveError
+		netErr      net.Error
+	)
+	switch {
+	case errors.As(err, &callbackErr):
+		switch callbackErr.Code {
+		case "access_denied":
+			return AuthDenied
+		case "invalid_client", "unauthorized_client":
+			return AuthInvalidClient
+		}
+	case errors.As(err, &retrieveErr):
+		switch retrieveErr.ErrorCode {
+		case "invalid_client", "unauthorized_client":
+			return AuthInvalidClient
+		case "invalid_grant":
+			return AuthInvalidGrant
+		}
+		if retrieveErr.Response != nil && retrieveErr.Response.StatusCode == http.StatusUnauthorized {
+			return AuthInvalidClient
+		}
+	case errors.Is(err, ErrInvalidToken):
+		return AuthInvalidGrant
+	case errors.As(err, &netErr):
+		return AuthNetwork
+	}
+	return AuthFailed
+}
+
+// validationError wraps err returned by the token validation into [AuthError]
+// if the token is rejected. Other errors are not related to authentication
+// and are returned as is.
+func validationError(err error) error {
+	var authErr *AuthError
+	if errors.As(err, &authErr) || errors.Is(err, ErrInvalidToken) || errors.Is(err, ErrInsufficientScope) {
+		return newAuthError(err)
+	}
+	return err
+}
+
+// permanentAuthError reports whether err is an authentication failure that
+// retrying the request cannot fix.
+func permanentAuthError(err error) bool {
+	var authErr *AuthError
+	return errors.As(err, &authErr) && authErr.Kind != AuthNetwork
+}
@@ -0,0 +1,121 @@
+package deviantart
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"github.com/leonidboykov/go-deviantart/internal/authserver"
+)
+
+func retrieveError(status int, code string) error {
+	return &oauth2.RetrieveError{
+		Response:  &http.Response{StatusCode: status},
+		ErrorCode: code,
+	}
+}
+
+func TestAuthErrorKind(t *testing.T) {
+	dialErr := &url.Error{Op: "Post", URL: "https://example.com/token", Err: &net.OpError{
+		Op:  "dial",
+		Net: "tcp",
+		Err: errors.New("connection refused"),
+	}}
+	for _, tc := range []struct {
+		name string
+		err  error
+		want AuthErrorKind
+	}{
+		{"access denied", &authserver.CallbackError{Code: "access_denied"}, AuthDenied},
+		{"callback invalid client", &authserver.CallbackError{Code: "unauthorized_client"}, AuthInvalidClient},
+		{"callback other", &authserver.CallbackError{Code: "server_error"}, AuthFailed},
+		{"network", fmt.Errorf("oauth2: cannot fetch token: %w", dialErr), AuthNetwork},
+		{"invalid client", retrieveError(http.StatusBadRequest, "invalid_client"), AuthInvalidClient},
+		{"unauthorized client", retrieveError(http.StatusBadRequest, "unauthorized_client"), AuthInvalidClient},
+		{"unauthorized status", retrieveError(http.StatusUnauthorized, ""), AuthInvalidClient},
+		{"invalid grant", retrieveError(http.StatusBadRequest, "invalid_grant"), AuthInvalidGrant},
+		{"invalid token", fmt.Errorf("request: %w", Error{Type: "invalid_token"}), AuthInvalidGrant},
+		{"other retrieve error", retrieveError(http.StatusInternalServerError, ""), AuthFailed},
+		{"other", errors.New("boom"), AuthFailed},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := authErrorKind(tc.err); got != tc.want {
+				t.Errorf("authErrorKind(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+			var authErr *AuthError
+			if err := newAuthError(tc.err); !errors.As(err, &authErr) || authErr.Kind != tc.want || !errors.Is(err, tc.err) {
+				t.Errorf("newAuthError(%v) = %v, want %v wrapping the error", tc.err, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestTokenValidation(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		status int
+		body   string
+		auth   bool
+		kind   AuthErrorKind
+		target error
+	}{
+		{
+			name:   "invalid token",
+			status: http.StatusUnauthorized,
+			body:   `{"error":"invalid_token","error_description":"Expired oAuth2 user token.","status":"error"}`,
+			auth:   true,
+			kind:   AuthInvalidGrant,
+			target: ErrInvalidToken,
+		},
+		{
+			name:   "insufficient scope",
+			status: http.StatusForbidden,
+			body:   `{"error":"insufficient_scope","error_description":"The request requires higher privileges.","status":"error"}`,
+			auth:   true,
+			kind:   AuthFailed,
+			target: ErrInsufficientScope,
+		},
+		{
+			name:   "server error",
+			status: http.StatusServiceUnavailable,
+			body:   `{"error":"server_error","error_description":"Service unavailable.","status":"error"}`,
+			target: ErrServer,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tc.status)
+				io.WriteString(w, tc.body)
+			}))
+			defer srv.Close()
+
+			_, err := NewClient(StaticToken("token"), WithBaseURL(srv.URL), WithRetryPolicy(RetryPolicy{}), WithTokenValidation())
+			if !errors.Is(err, tc.target) {
+				t.Fatalf("NewClient error = %v, want %v", err, tc.target)
+			}
+			var authErr *AuthError
+			if isAuth := errors.As(err, &authErr); isAuth != tc.auth {
+				t.Fatalf("NewClient error = %v, AuthError %t, want %t", err, isAuth, tc.auth)
+			}
+			if tc.auth && authErr.Kind != tc.kind {
+				t.Errorf("AuthError kind = %v, want %v", authErr.Kind, tc.kind)
+			}
+		})
+	}
+}
+
+func TestValidationErrorKeepsAuthError(t *testing.T) {
+	authErr := &AuthError{Kind: AuthNetwork, Err: errors.New("connection refused")}
+	err := fmt.Errorf("unable to validate access_token: %w", authErr)
+	if got := validationError(err); got != err {
+		t.Errorf("validationError = %v, want %v as is", got, err)
+	}
+}
@@ -0,0 +1,107 @@
+package deviantart
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+// callback returns a handler that answers with the code and the state sent
+// in the authorization URL, after checking it with check.
+func callback(t *testing.T, check func(query url.Values)) func(authCodeURL string) (string, string, error) {
+	return func(authCodeURL string) (string, string, error) {
+		uri, err := url.Parse(authCodeURL)
+		if err != nil {
+			t.Fatalf("parse auth code url: %v", err)
+		}
+		query := uri.Query()
+		if check != nil {
+			check(query)
+		}
+		return "code", query.Get("state"), nil
+	}
+}
+
+func TestAuthorizeStateMismatch(t *testing.T) {
+	srv := newOAuthServer(t)
+	conf := srv.config(false)
+
+	_, err := conf.authorize(context.Background(), conf.oauth2Config(), func(string) (string, string, error) {
+		return "code", "forged", nil
+	})
+	if !errors.Is(err, ErrStateMismatch) {
+		t.Fatalf("authorize error = %v, want %v", err, ErrStateMismatch)
+	}
+	if n := len(srv.tokenRequests()); n != 0 {
+		t.Errorf("token endpoint called %d times, want 0", n)
+	}
+}
+
+func TestAuthorizeRandomState(t *testing.T) {
+	srv := newOAuthServer(t)
+	conf := srv.config(false)
+
+	var states []string
+	for range 2 {
+		_, err := conf.authorize(context.Background(), conf.oauth2Config(), callback(t, func(query url.Values) {
+			states = append(states, query.Get("state"))
+		}))
+		if err != nil {
+			t.Fatalf("authorize: %v", err)
+		}
+	}
+	if states[0] == "" || states[0] == states[1] {
+		t.Errorf("states = %q, want distinct non-empty states", states)
+	}
+}
+
+func TestAuthorizePKCE(t *testing.T) {
+	srv := newOAuthServer(t)
+	conf := srv.config(true)
+
+	var challenge string
+	tok, err := conf.authorize(context.Background(), conf.oauth2Config(), callback(t, func(query url.Values) {
+		if method := query.Get("code_challenge_method"); method != "S256" {
+			t.Errorf("code_challenge_method = %q, want S256", method)
+		}
+		challenge = query.Get("code_challenge")
+	}))
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	if tok.AccessToken != "access" {
+		t.Errorf("access token = %q, want %q", tok.AccessToken, "access")
+	}
+
+	forms := srv.tokenRequests()
+	if len(forms) != 1 {
+		t.Fatalf("token endpoint called %d times, want 1", len(forms))
+	}
+	verifier := forms[0].Get("code_verifier")
+	if verifier == "" {
+		t.Fatal("code_verifier is not sent to the token endpoint")
+	}
+	if want := oauth2.S256ChallengeFromVerifier(verifier); challenge != want {
+		t.Errorf("code_challenge = %q, want %q derived from code_verifier", challenge, want)
+	}
+}
+
+func TestAuthorizeWithoutPKCE(t *testing.T) {
+	srv := newOAuthServer(t)
+	conf := srv.config(false)
+
+	_, err := conf.authorize(context.Background(), conf.oauth2Config(), callback(t, func(query url.Values) {
+		if query.Has("code_challenge") {
+			t.Error("code_challenge is sent with PKCE disabled")
+		}
+	}))
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	if forms := srv.tokenRequests(); len(forms) != 1 || forms[0].Has("code_verifier") {
+		t.Errorf("token requests = %v, want one without code_verifier", forms)
+	}
+}
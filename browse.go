@@ -1,7 +1,9 @@
 package deviantart
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"time"
 
 	"github.com/dghubble/sling"
@@ -30,6 +32,11 @@ func newBrowseService(sling *sling.Sling) *BrowseService {
 // TODO: The endpoint returns the `has_more` field, but there is no offset or
 // cursor pagination information. This case requires further investigation.
 func (s *BrowseService) DailyDeviations(date time.Time) (OffsetResponse[Deviation], error) {
+	return s.DailyDeviationsContext(context.Background(), date)
+}
+
+// DailyDeviationsContext is like [BrowseService.DailyDeviations] but uses ctx for the request.
+func (s *BrowseService) DailyDeviationsContext(ctx context.Context, date time.Time) (OffsetResponse[Deviation], error) {
 	type dateParams struct {
 		Date time.Time `url:"date,omitempty" layout:"2006-01-02"`
 	}
@@ -37,7 +44,7 @@ func (s *BrowseService) DailyDeviations(date time.Time) (OffsetResponse[Deviatio
 		success OffsetResponse[Deviation]
 		failure Error
 	)
-	_, err := s.sling.New().Get("dailydeviations").QueryStruct(&dateParams{Date: date}).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("dailydeviations").QueryStruct(&dateParams{Date: date}), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return OffsetResponse[Deviation]{}, fmt.Errorf("unable to fetch daily deviations: %w", err)
 	}
@@ -53,17 +60,29 @@ func (s *BrowseService) DailyDeviations(date time.Time) (OffsetResponse[Deviatio
 //
 //   - browse
 func (s *BrowseService) DeviantsYouWatch(page *OffsetParams) (OffsetResponse[Deviation], error) {
+	return s.DeviantsYouWatchContext(context.Background(), page)
+}
+
+// DeviantsYouWatchContext is like [BrowseService.DeviantsYouWatch] but uses ctx for the request.
+func (s *BrowseService) DeviantsYouWatchContext(ctx context.Context, page *OffsetParams) (OffsetResponse[Deviation], error) {
 	var (
 		success OffsetResponse[Deviation]
 		failure Error
 	)
-	_, err := s.sling.New().Get("deviantsyouwatch").QueryStruct(page).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("deviantsyouwatch").QueryStruct(page), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return OffsetResponse[Deviation]{}, fmt.Errorf("unable to fetch deviants for you: %w", err)
 	}
 	return success, nil
 }
 
+// DeviantsYouWatchIter iterates over all pages of [BrowseService.DeviantsYouWatch].
+func (s *BrowseService) DeviantsYouWatchIter(ctx context.Context, opts *IterOptions) iter.Seq2[Deviation, error] {
+	return iterOffset(ctx, opts, iterOrigin("Browse.DeviantsYouWatch"), func(ctx context.Context, page *OffsetParams) (OffsetResponse[Deviation], error) {
+		return s.DeviantsYouWatchContext(ctx, page)
+	})
+}
+
 type MoreLikeThisPreviewResponse struct {
 	Seed                 uuid.UUID   `json:"seed"`
 	Author               User        `json:"user"`
@@ -85,6 +104,11 @@ type MoreLikeThisPreviewResponse struct {
 //   - browse
 //   - browse.mlt
 func (s *BrowseService) MoreLikeThisPreview(seed uuid.UUID) (MoreLikeThisPreviewResponse, error) {
+	return s.MoreLikeThisPreviewContext(context.Background(), seed)
+}
+
+// MoreLikeThisPreviewContext is like [BrowseService.MoreLikeThisPreview] but uses ctx for the request.
+func (s *BrowseService) MoreLikeThisPreviewContext(ctx context.Context, seed uuid.UUID) (MoreLikeThisPreviewResponse, error) {
 	type seedParams struct {
 		Seed string `url:"seed"`
 	}
@@ -93,7 +117,7 @@ func (s *BrowseService) MoreLikeThisPreview(seed uuid.UUID) (MoreLikeThisPreview
 		failure Error
 	)
 	params := &seedParams{Seed: seed.String()}
-	_, err := s.sling.New().Get("morelikethis/preview").QueryStruct(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("morelikethis/preview").QueryStruct(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return MoreLikeThisPreviewResponse{}, fmt.Errorf("unable to fetch more like this: %w", err)
 	}
@@ -103,7 +127,7 @@ func (s *BrowseService) MoreLikeThisPreview(seed uuid.UUID) (MoreLikeThisPreview
 type searchParams struct {
 	// Search query term.
 	//
-	// Estimated total results count would be available on EstimatedTotal field.
+	// Use [BrowseService.Search] to get estimated total results count.
 	Query string `url:"q,omitempty"`
 }
 
@@ -116,18 +140,30 @@ type searchParams struct {
 //
 //   - browse
 func (s *BrowseService) Newest(query string, page *OffsetParams) (OffsetResponse[Deviation], error) {
+	return s.NewestContext(context.Background(), query, page)
+}
+
+// NewestContext is like [BrowseService.Newest] but uses ctx for the request.
+func (s *BrowseService) NewestContext(ctx context.Context, query string, page *OffsetParams) (OffsetResponse[Deviation], error) {
 	var (
 		success OffsetResponse[Deviation]
 		failure Error
 	)
 	params := &searchParams{Query: query}
-	_, err := s.sling.New().Get("newest").QueryStruct(params).QueryStruct(page).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("newest").QueryStruct(params).QueryStruct(page), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return OffsetResponse[Deviation]{}, fmt.Errorf("unable to fetch newest deviations: %w", err)
 	}
 	return success, nil
 }
 
+// NewestIter iterates over all pages of [BrowseService.Newest].
+func (s *BrowseService) NewestIter(ctx context.Context, query string, opts *IterOptions) iter.Seq2[Deviation, error] {
+	return iterOffset(ctx, opts, iterOrigin("Browse.Newest", query), func(ctx context.Context, page *OffsetParams) (OffsetResponse[Deviation], error) {
+		return s.NewestContext(ctx, query, page)
+	})
+}
+
 const (
 	TimeRangeNow   = "now"
 	TimeRangeWeek  = "1week"
@@ -138,7 +174,7 @@ const (
 type PopularParams struct {
 	// Search query term.
 	//
-	// Estimated total results count would be available on EstimatedTotal field.
+	// Use [BrowseService.Search] to get estimated total results count.
 	Query string `url:"q,omitempty"`
 
 	// The timerange.
@@ -159,17 +195,29 @@ type PopularParams struct {
 // BUG: Query does not work properly.
 // See: https://github.com/wix-incubator/DeviantArt-API/issues/206.
 func (s *BrowseService) Popular(params *PopularParams, page *OffsetParams) (OffsetResponse[Deviation], error) {
+	return s.PopularContext(context.Background(), params, page)
+}
+
+// PopularContext is like [BrowseService.Popular] but uses ctx for the request.
+func (s *BrowseService) PopularContext(ctx context.Context, params *PopularParams, page *OffsetParams) (OffsetResponse[Deviation], error) {
 	var (
 		success OffsetResponse[Deviation]
 		failure Error
 	)
-	_, err := s.sling.New().Get("popular").QueryStruct(params).QueryStruct(page).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("popular").QueryStruct(params).QueryStruct(page), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return OffsetResponse[Deviation]{}, fmt.Errorf("unable to fetch popular deviations: %w", err)
 	}
 	return success, nil
 }
 
+// PopularIter iterates over all pages of [BrowseService.Popular].
+func (s *BrowseService) PopularIter(ctx context.Context, params *PopularParams, opts *IterOptions) iter.Seq2[Deviation, error] {
+	return iterOffset(ctx, opts, iterOrigin("Browse.Popular", params), func(ctx context.Context, page *OffsetParams) (OffsetResponse[Deviation], error) {
+		return s.PopularContext(ctx, params, page)
+	})
+}
+
 type JournalStatus struct {
 	Journal *Deviation `json:"journal"`
 	Status  *Status    `json:"status"`
@@ -184,17 +232,29 @@ type JournalStatus struct {
 //
 //   - browse
 func (s *BrowseService) PostsDeviantsYouWatch(page *OffsetParams) (OffsetResponse[JournalStatus], error) {
+	return s.PostsDeviantsYouWatchContext(context.Background(), page)
+}
+
+// PostsDeviantsYouWatchContext is like [BrowseService.PostsDeviantsYouWatch] but uses ctx for the request.
+func (s *BrowseService) PostsDeviantsYouWatchContext(ctx context.Context, page *OffsetParams) (OffsetResponse[JournalStatus], error) {
 	var (
 		success OffsetResponse[JournalStatus]
 		failure Error
 	)
-	_, err := s.sling.New().Get("posts/deviantsyouwatch").QueryStruct(page).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("posts/deviantsyouwatch").QueryStruct(page), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return OffsetResponse[JournalStatus]{}, fmt.Errorf("unable to fetch deviants for you: %w", err)
 	}
 	return success, nil
 }
 
+// PostsDeviantsYouWatchIter iterates over all pages of [BrowseService.PostsDeviantsYouWatch].
+func (s *BrowseService) PostsDeviantsYouWatchIter(ctx context.Context, opts *IterOptions) iter.Seq2[JournalStatus, error] {
+	return iterOffset(ctx, opts, iterOrigin("Browse.PostsDeviantsYouWatch"), func(ctx context.Context, page *OffsetParams) (OffsetResponse[JournalStatus], error) {
+		return s.PostsDeviantsYouWatchContext(ctx, page)
+	})
+}
+
 // Recommended fetches recommended deviations.
 //
 // To connect to this endpoint OAuth2 Access Token from the Authorization Code
@@ -207,19 +267,39 @@ func (s *BrowseService) PostsDeviantsYouWatch(page *OffsetParams) (OffsetRespons
 // TODO: Documentation specifies the `suggested_reasons` field but is absend in
 // all responses. This case requires further investigation.
 func (s *BrowseService) Recommended(query string) (OffsetResponse[Deviation], error) {
+	return s.RecommendedContext(context.Background(), query)
+}
+
+// RecommendedContext is like [BrowseService.Recommended] but uses ctx for the request.
+func (s *BrowseService) RecommendedContext(ctx context.Context, query string) (OffsetResponse[Deviation], error) {
 	var (
 		success OffsetResponse[Deviation]
 		failure Error
 	)
 	params := &searchParams{Query: query}
-	_, err := s.sling.New().Get("recommended").QueryStruct(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("recommended").QueryStruct(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return OffsetResponse[Deviation]{}, fmt.Errorf("unable to fetch recommended deviations: %w", err)
 	}
 	return success, nil
 }
 
-// Tags fetches a tag.
+// TagsResponse is a page of deviations with a tag. Cursor fields are set when
+// the page is fetched in cursor mode, NextOffset is set in offset mode.
+type TagsResponse struct {
+	CursorResponse[Deviation]
+	NextOffset uint32 `json:"next_offset,omitempty"`
+}
+
+// NextOffsetPage returns params of the next page in offset mode.
+func (t *TagsResponse) NextOffsetPage() *OffsetParams {
+	return &OffsetParams{
+		Offset: t.NextOffset,
+	}
+}
+
+// Tags fetches a tag using cursor-based pagination, suitable for streaming.
+// Use [BrowseService.TagsOffset] to jump to arbitrary pages.
 //
 // To connect to this endpoint OAuth2 Access Token from the Client Credentials
 // Grant, or Authorization Code Grant is required.
@@ -227,20 +307,65 @@ func (s *BrowseService) Recommended(query string) (OffsetResponse[Deviation], er
 // The following scopes are required to access this resource:
 //
 //   - browse
+func (s *BrowseService) Tags(tag string, page *CursorParams) (TagsResponse, error) {
+	return s.TagsContext(context.Background(), tag, page)
+}
+
+// TagsContext is like [BrowseService.Tags] but uses ctx for the request.
+func (s *BrowseService) TagsContext(ctx context.Context, tag string, page *CursorParams) (TagsResponse, error) {
+	return s.tags(ctx, tag, page)
+}
+
+// TagsIter iterates over all pages of [BrowseService.Tags].
+func (s *BrowseService) TagsIter(ctx context.Context, tag string, opts *IterOptions) iter.Seq2[Deviation, error] {
+	return iterCursor(ctx, opts, iterOrigin("Browse.Tags", tag), func(ctx context.Context, page *CursorParams) (CursorResponse[Deviation], error) {
+		resp, err := s.TagsContext(ctx, tag, page)
+		return resp.CursorResponse, err
+	})
+}
+
+// TagsOffset fetches a tag using offset-based pagination, suitable for page
+// numbers and deep links. Use [BrowseService.Tags] for streaming.
+//
+// To connect to this endpoint OAuth2 Access Token from the Client Credentials
+// Grant, or Authorization Code Grant is required.
 //
-// NOTE: This endpoint supports cursor- and offset-base pagination.
-// But for simplicity, I'll stick to cursor params for now.
-func (s *BrowseService) Tags(tag string, page *CursorParams) (CursorResponse[Deviation], error) {
+// The following scopes are required to access this resource:
+//
+//   - browse
+func (s *BrowseService) TagsOffset(tag string, page *OffsetParams) (TagsResponse, error) {
+	return s.TagsOffsetContext(context.Background(), tag, page)
+}
+
+// TagsOffsetContext is like [BrowseService.TagsOffset] but uses ctx for the request.
+func (s *BrowseService) TagsOffsetContext(ctx context.Context, tag string, page *OffsetParams) (TagsResponse, error) {
+	return s.tags(ctx, tag, page)
+}
+
+// TagsOffsetIter iterates over all pages of [BrowseService.TagsOffset].
+func (s *BrowseService) TagsOffsetIter(ctx context.Context, tag string, opts *IterOptions) iter.Seq2[Deviation, error] {
+	return iterOffset(ctx, opts, iterOrigin("Browse.TagsOffset", tag), func(ctx context.Context, page *OffsetParams) (OffsetResponse[Deviation], error) {
+		resp, err := s.TagsOffsetContext(ctx, tag, page)
+		return OffsetResponse[Deviation]{
+			Results:    resp.Results,
+			HasMore:    resp.HasMore,
+			NextOffset: resp.NextOffset,
+		}, err
+	})
+}
+
+// tags fetches a tag with either cursor or offset pagination params.
+func (s *BrowseService) tags(ctx context.Context, tag string, page any) (TagsResponse, error) {
 	type tagParams struct {
 		Tag string `url:"tag"`
 	}
 	var (
-		success CursorResponse[Deviation]
+		success TagsResponse
 		failure Error
 	)
-	_, err := s.sling.New().Get("tags").QueryStruct(&tagParams{Tag: tag}).QueryStruct(page).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("tags").QueryStruct(&tagParams{Tag: tag}).QueryStruct(page), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
-		return CursorResponse[Deviation]{}, fmt.Errorf("unable to fetch tags: %w", err)
+		return TagsResponse{}, fmt.Errorf("unable to fetch tags: %w", err)
 	}
 	return success, nil
 }
@@ -257,6 +382,11 @@ func (s *BrowseService) Tags(tag string, page *CursorParams) (CursorResponse[Dev
 //
 //   - browse
 func (s *BrowseService) TagsSearch(tag string) ([]string, error) {
+	return s.TagsSearchContext(context.Background(), tag)
+}
+
+// TagsSearchContext is like [BrowseService.TagsSearch] but uses ctx for the request.
+func (s *BrowseService) TagsSearchContext(ctx context.Context, tag string) ([]string, error) {
 	type tagName struct {
 		Name string `json:"tag_name" url:"tag_name"`
 	}
@@ -264,7 +394,7 @@ func (s *BrowseService) TagsSearch(tag string) ([]string, error) {
 		success singleResponse[tagName]
 		failure Error
 	)
-	_, err := s.sling.New().Get("tags/search").QueryStruct(&tagName{Name: tag}).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("tags/search").QueryStruct(&tagName{Name: tag}), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return nil, fmt.Errorf("unable to search tags: %w", err)
 	}
@@ -285,6 +415,11 @@ func (s *BrowseService) TagsSearch(tag string) ([]string, error) {
 //
 //   - browse
 func (s *BrowseService) Topic(topic string, page *CursorParams) (CursorResponse[Deviation], error) {
+	return s.TopicContext(context.Background(), topic, page)
+}
+
+// TopicContext is like [BrowseService.Topic] but uses ctx for the request.
+func (s *BrowseService) TopicContext(ctx context.Context, topic string, page *CursorParams) (CursorResponse[Deviation], error) {
 	type topicParams struct {
 		Topic string `url:"topic"`
 	}
@@ -292,13 +427,20 @@ func (s *BrowseService) Topic(topic string, page *CursorParams) (CursorResponse[
 		success CursorResponse[Deviation]
 		failure Error
 	)
-	_, err := s.sling.New().Get("topic").QueryStruct(&topicParams{Topic: topic}).QueryStruct(page).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("topic").QueryStruct(&topicParams{Topic: topic}).QueryStruct(page), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return CursorResponse[Deviation]{}, fmt.Errorf("unable to fetch topic: %w", err)
 	}
 	return success, nil
 }
 
+// TopicIter iterates over all pages of [BrowseService.Topic].
+func (s *BrowseService) TopicIter(ctx context.Context, topic string, opts *IterOptions) iter.Seq2[Deviation, error] {
+	return iterCursor(ctx, opts, iterOrigin("Browse.Topic", topic), func(ctx context.Context, page *CursorParams) (CursorResponse[Deviation], error) {
+		return s.TopicContext(ctx, topic, page)
+	})
+}
+
 type Topic struct {
 	Name              string      `json:"name"`
 	CanonicalName     string      `json:"canonical_name"`
@@ -314,17 +456,29 @@ type Topic struct {
 //
 //   - browse
 func (s *BrowseService) Topics(page *CursorParams) (CursorResponse[Topic], error) {
+	return s.TopicsContext(context.Background(), page)
+}
+
+// TopicsContext is like [BrowseService.Topics] but uses ctx for the request.
+func (s *BrowseService) TopicsContext(ctx context.Context, page *CursorParams) (CursorResponse[Topic], error) {
 	var (
 		success CursorResponse[Topic]
 		failure Error
 	)
-	_, err := s.sling.New().Get("topics").QueryStruct(page).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("topics").QueryStruct(page), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return CursorResponse[Topic]{}, fmt.Errorf("unable to fetch topics: %w", err)
 	}
 	return success, nil
 }
 
+// TopicsIter iterates over all pages of [BrowseService.Topics].
+func (s *BrowseService) TopicsIter(ctx context.Context, opts *IterOptions) iter.Seq2[Topic, error] {
+	return iterCursor(ctx, opts, iterOrigin("Browse.Topics"), func(ctx context.Context, page *CursorParams) (CursorResponse[Topic], error) {
+		return s.TopicsContext(ctx, page)
+	})
+}
+
 // Topics fetches top topics with example deviation for each one.
 //
 // To connect to this endpoint OAuth2 Access Token from the Client Credentials
@@ -334,17 +488,29 @@ func (s *BrowseService) Topics(page *CursorParams) (CursorResponse[Topic], error
 //
 //   - browse
 func (s *BrowseService) TopTopics(page *CursorParams) (CursorResponse[Topic], error) {
+	return s.TopTopicsContext(context.Background(), page)
+}
+
+// TopTopicsContext is like [BrowseService.TopTopics] but uses ctx for the request.
+func (s *BrowseService) TopTopicsContext(ctx context.Context, page *CursorParams) (CursorResponse[Topic], error) {
 	var (
 		success CursorResponse[Topic]
 		failure Error
 	)
-	_, err := s.sling.New().Get("toptopics").QueryStruct(page).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("toptopics").QueryStruct(page), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return CursorResponse[Topic]{}, fmt.Errorf("unable to fetch topics: %w", err)
 	}
 	return success, nil
 }
 
+// TopTopicsIter iterates over all pages of [BrowseService.TopTopics].
+func (s *BrowseService) TopTopicsIter(ctx context.Context, opts *IterOptions) iter.Seq2[Topic, error] {
+	return iterCursor(ctx, opts, iterOrigin("Browse.TopTopics"), func(ctx context.Context, page *CursorParams) (CursorResponse[Topic], error) {
+		return s.TopTopicsContext(ctx, page)
+	})
+}
+
 type UserJournalsParams struct {
 	// The username of the user to fetch journals for.
 	Username string `url:"username"`
@@ -362,13 +528,25 @@ type UserJournalsParams struct {
 //
 //   - browse
 func (s *BrowseService) UserJournals(params *UserJournalsParams, page *OffsetParams) (OffsetResponse[Deviation], error) {
+	return s.UserJournalsContext(context.Background(), params, page)
+}
+
+// UserJournalsContext is like [BrowseService.UserJournals] but uses ctx for the request.
+func (s *BrowseService) UserJournalsContext(ctx context.Context, params *UserJournalsParams, page *OffsetParams) (OffsetResponse[Deviation], error) {
 	var (
 		success OffsetResponse[Deviation]
 		failure Error
 	)
-	_, err := s.sling.New().Get("user/journals").QueryStruct(params).QueryStruct(page).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("user/journals").QueryStruct(params).QueryStruct(page), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return OffsetResponse[Deviation]{}, fmt.Errorf("unable to browse user journals: %w", err)
 	}
 	return success, nil
 }
+
+// UserJournalsIter iterates over all pages of [BrowseService.UserJournals].
+func (s *BrowseService) UserJournalsIter(ctx context.Context, params *UserJournalsParams, opts *IterOptions) iter.Seq2[Deviation, error] {
+	return iterOffset(ctx, opts, iterOrigin("Browse.UserJournals", params), func(ctx context.Context, page *OffsetParams) (OffsetResponse[Deviation], error) {
+		return s.UserJournalsContext(ctx, params, page)
+	})
+}
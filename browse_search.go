@@ -0,0 +1,96 @@
+package deviantart
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// SearchOrder defines the order of search results.
+type SearchOrder uint8
+
+const (
+	// SearchNewest orders results by publication date, newest first.
+	SearchNewest SearchOrder = iota
+
+	// SearchPopular orders results by popularity within the time range.
+	SearchPopular
+)
+
+// SearchParams defines a deviation search.
+type SearchParams struct {
+	// Search query term.
+	Query string `url:"q"`
+
+	// The order of results. Default order is [SearchNewest].
+	Order SearchOrder `url:"-"`
+
+	// The timerange, used with [SearchPopular] order only. See TimeRange
+	// constants for valid values.
+	TimeRange string `url:"timerange,omitempty"`
+}
+
+// SearchResult is a page of deviations matching a search query.
+type SearchResult struct {
+	OffsetResponse[Deviation]
+
+	// Estimated total number of deviations matching the query.
+	EstimatedTotal uint32 `json:"estimated_total"`
+
+	// The query the results were searched with.
+	Query string `json:"-"`
+
+	// The order of results.
+	Order SearchOrder `json:"-"`
+}
+
+// Search searches deviations by query.
+//
+// To connect to this endpoint OAuth2 Access Token from the Client Credentials
+// Grant, or Authorization Code Grant is required.
+//
+// The following scopes are required to access this resource:
+//
+//   - browse
+func (s *BrowseService) Search(params *SearchParams, page *OffsetParams) (SearchResult, error) {
+	return s.SearchContext(context.Background(), params, page)
+}
+
+// SearchContext is like [BrowseService.Search] but uses ctx for the request.
+func (s *BrowseService) SearchContext(ctx context.Context, params *SearchParams, page *OffsetParams) (SearchResult, error) {
+	var query SearchParams
+	if params != nil {
+		query = *params
+	}
+	query.Query = strings.TrimSpace(query.Query)
+	path := "newest"
+	switch query.Order {
+	case SearchNewest:
+		query.TimeRange = ""
+	case SearchPopular:
+		path = "popular"
+	default:
+		return SearchResult{}, fmt.Errorf("unable to search deviations: unknown order %d", query.Order)
+	}
+
+	var (
+		success SearchResult
+		failure Error
+	)
+	_, err := receive(ctx, s.sling.New().Get(path).QueryStruct(&query).QueryStruct(page), &success, &failure)
+	if err := relevantError(err, failure); err != nil {
+		return SearchResult{}, fmt.Errorf("unable to search deviations: %w", err)
+	}
+	success.Query = query.Query
+	success.Order = query.Order
+	return success, nil
+}
+
+// SearchIter iterates over all pages of [BrowseService.Search].
+func (s *BrowseService) SearchIter(ctx context.Context, params *SearchParams, opts *IterOptions) iter.Seq2[Deviation, error] {
+	return iterOffset(ctx, opts, iterOrigin("Browse.Search", params), func(ctx context.Context, page *OffsetParams) (OffsetResponse[Deviation], error) {
+		result, err := s.SearchContext(ctx, params, page)
+		return result.OffsetResponse, err
+	})
+}
@@ -1,7 +1,16 @@
 package deviantart
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
 	"github.com/dghubble/sling"
+	"golang.org/x/oauth2"
+
+	"github.com/leonidboykov/go-deviantart/internal/ratelimit"
 )
 
 const deviantArtURL = "https://www.deviantart.com/api/v1/oauth2/"
@@ -13,6 +22,11 @@ type StatusResponse struct {
 // Client provices access to DeviantArt API endpoint.
 type Client struct {
 	base        *sling.Sling
+	retrier     *ratelimit.HTTPClient
+	tokenSource oauth2.TokenSource
+	session     *sessionTokenSource
+	httpClient  *http.Client
+	revokeURL   string
 	Browse      *BrowseService
 	Collections *CollectionsService
 	Comments    *CommentsService
@@ -23,22 +37,140 @@ type Client struct {
 	User        *UserService
 }
 
-type withMatureContentParams struct {
-	MatureContent bool `url:"mature_content"`
+type clientOptions struct {
+	httpClient *http.Client
+	baseURL    string
+	userAgent  string
+
+	maturePolicy MatureContentPolicy
+	retryPolicy  RetryPolicy
+
+	limiter          *RateLimiter
+	endpointLimiters map[string]*RateLimiter
+
+	validateToken bool
+	checkScopes   bool
+
+	revokeURL string
+
+	recovery bool
+	reauth   Authenticator
+
+	middleware        []Middleware
+	attemptMiddleware []Middleware
+}
+
+// Option configures a [Client] created by [NewClient].
+type Option func(*clientOptions)
+
+// WithHTTPClient sets the HTTP client used for API and OAuth2 token requests.
+// Its transport is wrapped with authentication, so proxies, TLS settings and
+// instrumentation configured on it apply to every request. Timeout,
+// CheckRedirect and Jar of the client are kept for API requests; Timeout
+// limits every attempt separately when a request is retried.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *clientOptions) {
+		o.httpClient = client
+	}
+}
+
+// WithBaseURL overrides the DeviantArt API base URL. It is useful to point the
+// client at a local stand-in server in tests.
+func WithBaseURL(baseURL string) Option {
+	return func(o *clientOptions) {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		o.baseURL = baseURL
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(o *clientOptions) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithTokenValidation makes [NewClient] call [Client.Placebo] to confirm the
+// token is valid, so authentication failures are reported right away as
+// [AuthError] instead of failing the first request. Other failures, e.g. an
+// unavailable API, are returned as is.
+func WithTokenValidation() Option {
+	return func(o *clientOptions) {
+		o.validateToken = true
+	}
 }
 
-// TODO: Add http.Client to args.
-func NewClient(auth Authenticator) (*Client, error) {
-	sling := sling.New().Base(deviantArtURL)
-	if err := auth(sling); err != nil {
+// NewClient creates a DeviantArt API client authenticated with auth.
+func NewClient(auth Authenticator, opts ...Option) (*Client, error) {
+	o := &clientOptions{
+		httpClient:  http.DefaultClient,
+		baseURL:     deviantArtURL,
+		retryPolicy: DefaultRetryPolicy(),
+		revokeURL:   revokeURL,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	baseURL, err := url.Parse(o.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse base url: %w", err)
+	}
+
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, o.httpClient)
+	ts, err := auth(ctx)
+	if err != nil {
 		return nil, err
 	}
 
-	// TODO: Make it customizable.
-	sling.QueryStruct(&withMatureContentParams{true})
+	var (
+		source     = oauth2.ReuseTokenSource(nil, ts)
+		recovering *recoveringTokenSource
+	)
+	if o.recovery {
+		// The token source is replaced during recovery, so it must not be
+		// cached by the transport.
+		recovering = newRecoveringTokenSource(ctx, ts, o.reauth)
+		ts, source = recovering, recovering
+	}
+	session := newSessionTokenSource(ts, source)
+	ts = session
+	// Copy the client instead of using oauth2.NewClient, which keeps the
+	// transport only.
+	httpClient := *o.httpClient
+	httpClient.Transport = &oauth2.Transport{Base: o.httpClient.Transport, Source: session}
+	var transport Doer = chain(&httpClient, o.attemptMiddleware)
+	if o.limiter != nil || len(o.endpointLimiters) > 0 {
+		transport = ratelimit.NewThrottler(transport, baseURL.Path, o.limiter, o.endpointLimiters)
+	}
+	retrier := ratelimit.NewHTTPClient(transport, o.retryPolicy)
+	retrier.Permanent = permanentAuthError
+	var next Doer = retrier
+	if recovering != nil {
+		next = &recoveryDoer{next: retrier, ts: recovering}
+	}
+	next = chain(next, o.middleware)
+	var doer Doer = &matureContentDoer{
+		next:   &responseDoer{next: next},
+		policy: o.maturePolicy,
+	}
+	if o.checkScopes {
+		doer = &scopeDoer{next: doer, ts: ts, basePath: baseURL.Path}
+	}
+	sling := sling.New().Base(o.baseURL).Doer(doer)
+	if o.userAgent != "" {
+		sling.Set("User-Agent", o.userAgent)
+	}
 
 	c := &Client{
 		base:        sling,
+		retrier:     retrier,
+		tokenSource: ts,
+		session:     session,
+		httpClient:  o.httpClient,
+		revokeURL:   o.revokeURL,
 		Browse:      newBrowseService(sling.New()),
 		Collections: newCollectionsService(sling.New()),
 		Comments:    newCommentsService(sling.New()),
@@ -48,5 +180,29 @@ func NewClient(auth Authenticator) (*Client, error) {
 		Stash:       newStashService(sling.New()),
 		User:        newUserService(sling.New()),
 	}
+	if o.validateToken {
+		if err := c.Placebo(); err != nil {
+			return nil, validationError(err)
+		}
+	}
 	return c, nil
 }
+
+// receive is like [sling.Sling.Receive] but sends the request with ctx, so the
+// call and any pending retry are aborted once ctx is done. The status code and
+// headers of an error response are attached to failureV if it is an [Error].
+func receive(ctx context.Context, s *sling.Sling, successV, failureV any) (*http.Response, error) {
+	req, err := s.Request()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.Do(req.WithContext(ctx), successV, failureV)
+	if apiErr, ok := failureV.(*Error); ok && resp != nil && (resp.StatusCode < 200 || resp.StatusCode > 299) {
+		// Error responses are not always JSON encoded, the status is enough
+		// to describe them.
+		apiErr.StatusCode = resp.StatusCode
+		apiErr.Header = resp.Header
+		err = nil
+	}
+	return resp, err
+}
@@ -1,6 +1,7 @@
 package deviantart
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/dghubble/sling"
@@ -37,6 +38,11 @@ type faveParams struct {
 	FolderIDs []uuid.UUID `url:"folderid,omitempty"`
 }
 
+type faveResponse struct {
+	StatusResponse
+	Favourites int `json:"favourites"`
+}
+
 // Fave adds deviation to favourites.
 //
 // You can add deviation to multiple collections at once. If you omit `folderID`
@@ -56,15 +62,20 @@ type faveParams struct {
 //   - browse
 //   - collection
 func (s *CollectionsService) Fave(deviationID uuid.UUID, folderIDs ...uuid.UUID) (int, error) {
+	return s.FaveContext(context.Background(), deviationID, folderIDs...)
+}
+
+// FaveContext is like [CollectionsService.Fave] but uses ctx for the request.
+func (s *CollectionsService) FaveContext(ctx context.Context, deviationID uuid.UUID, folderIDs ...uuid.UUID) (int, error) {
 	var (
-		success map[string]any
+		success faveResponse
 		failure Error
 	)
-	_, err := s.sling.New().Post("fave").BodyForm(&faveParams{DeviationID: deviationID, FolderIDs: folderIDs}).Receive(&success, &failure)
-	if err != nil {
+	_, err := receive(ctx, s.sling.New().Post("fave").BodyForm(&faveParams{DeviationID: deviationID, FolderIDs: folderIDs}), &success, &failure)
+	if err := relevantError(err, failure); err != nil {
 		return 0, fmt.Errorf("unable to fave the deviation: %w", err)
 	}
-	return success["favourites"].(int), nil
+	return success.Favourites, nil
 }
 
 // Unfave removes deviation from favourites.
@@ -87,13 +98,18 @@ func (s *CollectionsService) Fave(deviationID uuid.UUID, folderIDs ...uuid.UUID)
 //   - browse
 //   - collection
 func (s *CollectionsService) Unfave(deviationID uuid.UUID, folderIDs ...uuid.UUID) (int, error) {
+	return s.UnfaveContext(context.Background(), deviationID, folderIDs...)
+}
+
+// UnfaveContext is like [CollectionsService.Unfave] but uses ctx for the request.
+func (s *CollectionsService) UnfaveContext(ctx context.Context, deviationID uuid.UUID, folderIDs ...uuid.UUID) (int, error) {
 	var (
-		success map[string]any
+		success faveResponse
 		failure Error
 	)
-	_, err := s.sling.New().Post("unfave").BodyForm(&faveParams{DeviationID: deviationID, FolderIDs: folderIDs}).Receive(&success, &failure)
-	if err != nil {
+	_, err := receive(ctx, s.sling.New().Post("unfave").BodyForm(&faveParams{DeviationID: deviationID, FolderIDs: folderIDs}), &success, &failure)
+	if err := relevantError(err, failure); err != nil {
 		return 0, fmt.Errorf("unable to unfave the deviation: %w", err)
 	}
-	return success["favourites"].(int), nil
+	return success.Favourites, nil
 }
@@ -1,7 +1,9 @@
 package deviantart
 
 import (
+	"context"
 	"fmt"
+	"iter"
 
 	"github.com/dghubble/sling"
 	"github.com/google/uuid"
@@ -88,17 +90,39 @@ type CommentSiblings struct {
 //
 //   - browse
 func (s *CommentsService) CommentSiblings(commentID uuid.UUID, params *CommentSiblingsParams) (CommentSiblings, error) {
+	return s.CommentSiblingsContext(context.Background(), commentID, params)
+}
+
+// CommentSiblingsContext is like [CommentsService.CommentSiblings] but uses ctx for the request.
+func (s *CommentsService) CommentSiblingsContext(ctx context.Context, commentID uuid.UUID, params *CommentSiblingsParams) (CommentSiblings, error) {
 	var (
 		success CommentSiblings
 		failure Error
 	)
-	_, err := s.sling.New().Get(commentID.String()+"/").Path("siblings").Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get(commentID.String()+"/").Path("siblings").QueryStruct(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return CommentSiblings{}, fmt.Errorf("unable to fetch comment siblings: %w", err)
 	}
 	return success, nil
 }
 
+// CommentSiblingsIter iterates over all pages of [CommentsService.CommentSiblings].
+func (s *CommentsService) CommentSiblingsIter(ctx context.Context, commentID uuid.UUID, params *CommentSiblingsParams, opts *IterOptions) iter.Seq2[Comment, error] {
+	return iterOffset(ctx, opts, iterOrigin("Comments.CommentSiblings", commentID, params), func(ctx context.Context, page *OffsetParams) (OffsetResponse[Comment], error) {
+		p := CommentSiblingsParams{}
+		if params != nil {
+			p = *params
+		}
+		p.Offset, p.Limit = int(page.Offset), int(page.Limit)
+		siblings, err := s.CommentSiblingsContext(ctx, commentID, &p)
+		return OffsetResponse[Comment]{
+			Results:    siblings.Thread,
+			HasMore:    siblings.HasMore,
+			NextOffset: uint32(siblings.NextOffset),
+		}, err
+	})
+}
+
 type FetchCommentsParams struct {
 	// The commentid you want to fetch.
 	CommentID uuid.UUID `url:"commentid,omitempty"`
@@ -122,6 +146,15 @@ type CommentsResponse struct {
 	Thread     []Comment `json:"thread,omitempty"`
 }
 
+// commentsPage converts comments response to a page of comments.
+func commentsPage(resp CommentsResponse, err error) (OffsetResponse[Comment], error) {
+	return OffsetResponse[Comment]{
+		Results:    resp.Thread,
+		HasMore:    resp.HasMore,
+		NextOffset: uint32(resp.NextOffset),
+	}, err
+}
+
 // DeviationComments fetch comments posted on deviation.
 //
 // To connect to this endpoint OAuth2 Access Token from the Client Credentials
@@ -131,17 +164,34 @@ type CommentsResponse struct {
 //
 //   - browse
 func (s *CommentsService) DeviationComments(deviationID uuid.UUID, params *FetchCommentsParams) (CommentsResponse, error) {
+	return s.DeviationCommentsContext(context.Background(), deviationID, params)
+}
+
+// DeviationCommentsContext is like [CommentsService.DeviationComments] but uses ctx for the request.
+func (s *CommentsService) DeviationCommentsContext(ctx context.Context, deviationID uuid.UUID, params *FetchCommentsParams) (CommentsResponse, error) {
 	var (
 		success CommentsResponse
 		failure Error
 	)
-	_, err := s.sling.New().Get("deviation/").Path(deviationID.String()).QueryStruct(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("deviation/").Path(deviationID.String()).QueryStruct(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return CommentsResponse{}, fmt.Errorf("unable to fetch deviation comments: %w", err)
 	}
 	return success, nil
 }
 
+// DeviationCommentsIter iterates over all pages of [CommentsService.DeviationComments].
+func (s *CommentsService) DeviationCommentsIter(ctx context.Context, deviationID uuid.UUID, params *FetchCommentsParams, opts *IterOptions) iter.Seq2[Comment, error] {
+	return iterOffset(ctx, opts, iterOrigin("Comments.DeviationComments", deviationID, params), func(ctx context.Context, page *OffsetParams) (OffsetResponse[Comment], error) {
+		p := FetchCommentsParams{}
+		if params != nil {
+			p = *params
+		}
+		p.Offset, p.Limit = int(page.Offset), int(page.Limit)
+		return commentsPage(s.DeviationCommentsContext(ctx, deviationID, &p))
+	})
+}
+
 // ProfileComments fetch comments posted on user profile.
 //
 // To connect to this endpoint OAuth2 Access Token from the Client Credentials
@@ -151,17 +201,34 @@ func (s *CommentsService) DeviationComments(deviationID uuid.UUID, params *Fetch
 //
 //   - browse
 func (s *CommentsService) ProfileComments(username string, params *FetchCommentsParams) (CommentsResponse, error) {
+	return s.ProfileCommentsContext(context.Background(), username, params)
+}
+
+// ProfileCommentsContext is like [CommentsService.ProfileComments] but uses ctx for the request.
+func (s *CommentsService) ProfileCommentsContext(ctx context.Context, username string, params *FetchCommentsParams) (CommentsResponse, error) {
 	var (
 		success CommentsResponse
 		failure Error
 	)
-	_, err := s.sling.New().Get("profile/").Path(username).QueryStruct(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("profile/").Path(username).QueryStruct(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return CommentsResponse{}, fmt.Errorf("unable to fetch profile comments: %w", err)
 	}
 	return success, nil
 }
 
+// ProfileCommentsIter iterates over all pages of [CommentsService.ProfileComments].
+func (s *CommentsService) ProfileCommentsIter(ctx context.Context, username string, params *FetchCommentsParams, opts *IterOptions) iter.Seq2[Comment, error] {
+	return iterOffset(ctx, opts, iterOrigin("Comments.ProfileComments", username, params), func(ctx context.Context, page *OffsetParams) (OffsetResponse[Comment], error) {
+		p := FetchCommentsParams{}
+		if params != nil {
+			p = *params
+		}
+		p.Offset, p.Limit = int(page.Offset), int(page.Limit)
+		return commentsPage(s.ProfileCommentsContext(ctx, username, &p))
+	})
+}
+
 // StatusComments fetch comments posted on status.
 //
 // To connect to this endpoint OAuth2 Access Token from the Client Credentials
@@ -171,17 +238,34 @@ func (s *CommentsService) ProfileComments(username string, params *FetchComments
 //
 //   - browse
 func (s *CommentsService) StatusComments(statusID uuid.UUID, params *FetchCommentsParams) (CommentsResponse, error) {
+	return s.StatusCommentsContext(context.Background(), statusID, params)
+}
+
+// StatusCommentsContext is like [CommentsService.StatusComments] but uses ctx for the request.
+func (s *CommentsService) StatusCommentsContext(ctx context.Context, statusID uuid.UUID, params *FetchCommentsParams) (CommentsResponse, error) {
 	var (
 		success CommentsResponse
 		failure Error
 	)
-	_, err := s.sling.New().Get("status/").Path(statusID.String()).QueryStruct(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("status/").Path(statusID.String()).QueryStruct(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return CommentsResponse{}, fmt.Errorf("unable to fetch status comments: %w", err)
 	}
 	return success, nil
 }
 
+// StatusCommentsIter iterates over all pages of [CommentsService.StatusComments].
+func (s *CommentsService) StatusCommentsIter(ctx context.Context, statusID uuid.UUID, params *FetchCommentsParams, opts *IterOptions) iter.Seq2[Comment, error] {
+	return iterOffset(ctx, opts, iterOrigin("Comments.StatusComments", statusID, params), func(ctx context.Context, page *OffsetParams) (OffsetResponse[Comment], error) {
+		p := FetchCommentsParams{}
+		if params != nil {
+			p = *params
+		}
+		p.Offset, p.Limit = int(page.Offset), int(page.Limit)
+		return commentsPage(s.StatusCommentsContext(ctx, statusID, &p))
+	})
+}
+
 type CommentParams struct {
 	// The Comment ID you are replying to.
 	CommentID uuid.UUID `url:"commentid,omitempty"`
@@ -200,11 +284,16 @@ type CommentParams struct {
 //   - browse
 //   - comment.post
 func (s *CommentsService) CommentDeviation(deviationID uuid.UUID, params *CommentParams) (Comment, error) {
+	return s.CommentDeviationContext(context.Background(), deviationID, params)
+}
+
+// CommentDeviationContext is like [CommentsService.CommentDeviation] but uses ctx for the request.
+func (s *CommentsService) CommentDeviationContext(ctx context.Context, deviationID uuid.UUID, params *CommentParams) (Comment, error) {
 	var (
 		success Comment
 		failure Error
 	)
-	_, err := s.sling.New().Post("post/deviation/").Path(deviationID.String()).BodyForm(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Post("post/deviation/").Path(deviationID.String()).BodyForm(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return Comment{}, fmt.Errorf("unable to comment a deviation: %w", err)
 	}
@@ -221,11 +310,16 @@ func (s *CommentsService) CommentDeviation(deviationID uuid.UUID, params *Commen
 //   - browse
 //   - comment.post
 func (s *CommentsService) CommentProfile(username string, params *CommentParams) (Comment, error) {
+	return s.CommentProfileContext(context.Background(), username, params)
+}
+
+// CommentProfileContext is like [CommentsService.CommentProfile] but uses ctx for the request.
+func (s *CommentsService) CommentProfileContext(ctx context.Context, username string, params *CommentParams) (Comment, error) {
 	var (
 		success Comment
 		failure Error
 	)
-	_, err := s.sling.New().Post("post/profile/").Path(username).BodyForm(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Post("post/profile/").Path(username).BodyForm(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return Comment{}, fmt.Errorf("unable to comment a profile: %w", err)
 	}
@@ -242,11 +336,16 @@ func (s *CommentsService) CommentProfile(username string, params *CommentParams)
 //   - browse
 //   - comment.post
 func (s *CommentsService) CommentStatus(statusID uuid.UUID, params *CommentParams) (Comment, error) {
+	return s.CommentStatusContext(context.Background(), statusID, params)
+}
+
+// CommentStatusContext is like [CommentsService.CommentStatus] but uses ctx for the request.
+func (s *CommentsService) CommentStatusContext(ctx context.Context, statusID uuid.UUID, params *CommentParams) (Comment, error) {
 	var (
 		success Comment
 		failure Error
 	)
-	_, err := s.sling.New().Post("post/status/").Path(statusID.String()).BodyForm(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Post("post/status/").Path(statusID.String()).BodyForm(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return Comment{}, fmt.Errorf("unable to comment a status: %w", err)
 	}
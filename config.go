@@ -0,0 +1,204 @@
+package deviantart
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Grant types supported by [AuthProfile].
+const (
+	GrantClientCredentials = "client_credentials"
+	GrantAuthorizationCode = "authorization_code"
+)
+
+// DefaultAuthProfile is the name of the profile used when no name is given.
+const DefaultAuthProfile = "default"
+
+// Environment variables read by [AuthProfileFromEnv].
+const (
+	EnvGrant          = "DEVIANTART_GRANT"
+	EnvClientID       = "DEVIANTART_CLIENT_ID"
+	EnvClientSecret   = "DEVIANTART_CLIENT_SECRET"
+	EnvScopes         = "DEVIANTART_SCOPES"
+	EnvCallbackURL    = "DEVIANTART_CALLBACK_URL"
+	EnvTokenStorePath = "DEVIANTART_TOKEN_STORE_PATH"
+	EnvTokenStoreKey  = "DEVIANTART_TOKEN_STORE_KEY"
+)
+
+// AuthProfile holds application credentials and settings needed to build an
+// [Authenticator].
+type AuthProfile struct {
+	// The grant type, either [GrantClientCredentials] or
+	// [GrantAuthorizationCode]. Default is [GrantClientCredentials].
+	Grant string `json:"grant,omitempty"`
+
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+
+	// Scopes requested by the Authorization Code grant.
+	Scopes []string `json:"scopes,omitempty"`
+
+	// Redirect URL of the Authorization Code grant. Default is
+	// [DefaultCallbackURL].
+	CallbackURL string `json:"callback_url,omitempty"`
+
+	// Path of the file to keep tokens of the Authorization Code grant in, see
+	// [FileTokenStore]. Tokens are not kept if empty.
+	TokenStorePath string `json:"token_store_path,omitempty"`
+
+	// Base64-encoded key to encrypt the token file with, see
+	// [NewFileTokenStore]. It requires TokenStorePath.
+	TokenStoreKey string `json:"token_store_key,omitempty"`
+}
+
+// AuthProfileFromEnv reads a profile from environment variables. Scopes are
+// separated by spaces or commas.
+func AuthProfileFromEnv() (AuthProfile, error) {
+	p := AuthProfile{
+		Grant:          os.Getenv(EnvGrant),
+		ClientID:       os.Getenv(EnvClientID),
+		ClientSecret:   os.Getenv(EnvClientSecret),
+		Scopes:         splitScopes(os.Getenv(EnvScopes)),
+		CallbackURL:    os.Getenv(EnvCallbackURL),
+		TokenStorePath: os.Getenv(EnvTokenStorePath),
+		TokenStoreKey:  os.Getenv(EnvTokenStoreKey),
+	}
+	if err := p.validate(); err != nil {
+		return AuthProfile{}, fmt.Errorf("unable to read profile from environment: %w", err)
+	}
+	return p, nil
+}
+
+// DefaultConfigPath returns the path of the config file in the user config
+// directory, e.g. ~/.config/deviantart/config.json on Linux.
+func DefaultConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to find config directory: %w", err)
+	}
+	return filepath.Join(dir, "deviantart", "config.json"), nil
+}
+
+// LoadAuthProfile reads the named profile from the JSON config file at path.
+// The file holds profiles by name:
+//
+//	{
+//	  "profiles": {
+//	    "default": {"client_id": "...", "client_secret": "..."},
+//	    "uploader": {
+//	      "grant": "authorization_code",
+//	      "client_id": "...",
+//	      "client_secret": "...",
+//	      "scopes": ["browse", "stash", "publish"],
+//	      "token_store_path": "/home/user/.config/deviantart/uploader.json"
+//	    }
+//	  }
+//	}
+//
+// Empty name means [DefaultAuthProfile].
+func LoadAuthProfile(path, name string) (AuthProfile, error) {
+	if name == "" {
+		name = DefaultAuthProfile
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return AuthProfile{}, fmt.Errorf("unable to read config: %w", err)
+	}
+	var conf struct {
+		Profiles map[string]AuthProfile `json:"profiles"`
+	}
+	if err := json.Unmarshal(data, &conf); err != nil {
+		return AuthProfile{}, fmt.Errorf("unable to decode config: %w", err)
+	}
+	p, ok := conf.Profiles[name]
+	if !ok {
+		return AuthProfile{}, fmt.Errorf("unable to find profile %q in config", name)
+	}
+	if err := p.validate(); err != nil {
+		return AuthProfile{}, fmt.Errorf("unable to read profile %q: %w", name, err)
+	}
+	return p, nil
+}
+
+// validate checks that the profile is complete and has no settings the grant
+// would ignore.
+func (p AuthProfile) validate() error {
+	if p.ClientID == "" {
+		return errors.New("client id is missing")
+	}
+	if p.ClientSecret == "" {
+		return errors.New("client secret is missing")
+	}
+	switch p.Grant {
+	case "", GrantClientCredentials:
+		var unsupported []string
+		if len(p.Scopes) > 0 {
+			unsupported = append(unsupported, "scopes")
+		}
+		if p.CallbackURL != "" {
+			unsupported = append(unsupported, "callback url")
+		}
+		if p.TokenStorePath != "" {
+			unsupported = append(unsupported, "token store path")
+		}
+		if p.TokenStoreKey != "" {
+			unsupported = append(unsupported, "token store key")
+		}
+		if len(unsupported) > 0 {
+			return fmt.Errorf("%s not supported by %s grant", strings.Join(unsupported, ", "), GrantClientCredentials)
+		}
+	case GrantAuthorizationCode:
+		if p.TokenStoreKey != "" && p.TokenStorePath == "" {
+			return errors.New("token store key is set without token store path")
+		}
+	default:
+		return fmt.Errorf("unknown grant %q", p.Grant)
+	}
+	return nil
+}
+
+// Authenticator returns an [Authenticator] for the profile.
+func (p AuthProfile) Authenticator() (Authenticator, error) {
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	if p.Grant != GrantAuthorizationCode {
+		return ClientCredentials(p.ClientID, p.ClientSecret), nil
+	}
+
+	conf := &AuthorizationCodeConfig{
+		ClientID:     p.ClientID,
+		ClientSecret: p.ClientSecret,
+		Scopes:       p.Scopes,
+		RedirectURL:  p.CallbackURL,
+	}
+	if conf.RedirectURL == "" {
+		conf.RedirectURL = DefaultCallbackURL
+	}
+	if p.TokenStorePath != "" {
+		var key []byte
+		if p.TokenStoreKey != "" {
+			var err error
+			if key, err = base64.StdEncoding.DecodeString(p.TokenStoreKey); err != nil {
+				return nil, fmt.Errorf("unable to decode token store key: %w", err)
+			}
+		}
+		store, err := NewFileTokenStore(p.TokenStorePath, key)
+		if err != nil {
+			return nil, err
+		}
+		conf.TokenStore = store
+	}
+	return conf.Authenticator(), nil
+}
+
+func splitScopes(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+}
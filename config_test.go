@@ -0,0 +1,141 @@
+package deviantart
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testConfig = `{
+  "profiles": {
+    "default": {"client_id": "id", "client_secret": "secret"},
+    "uploader": {
+      "grant": "authorization_code",
+      "client_id": "id",
+      "client_secret": "secret",
+      "scopes": ["browse", "stash", "publish"],
+      "callback_url": "http://localhost:9000/callback",
+      "token_store_path": "/tmp/uploader.json"
+    },
+    "scoped": {"client_id": "id", "client_secret": "secret", "scopes": ["browse"]},
+    "public": {"client_id": "id"}
+  }
+}`
+
+func TestLoadAuthProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(testConfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		want AuthProfile
+		err  string
+	}{
+		{name: "", want: AuthProfile{ClientID: "id", ClientSecret: "secret"}},
+		{name: "uploader", want: AuthProfile{
+			Grant:          GrantAuthorizationCode,
+			ClientID:       "id",
+			ClientSecret:   "secret",
+			Scopes:         []string{BrowseScope, StashScope, PublishScope},
+			CallbackURL:    "http://localhost:9000/callback",
+			TokenStorePath: "/tmp/uploader.json",
+		}},
+		{name: "scoped", err: "scopes not supported by client_credentials grant"},
+		{name: "public", err: "client secret is missing"},
+		{name: "unknown", err: `unable to find profile "unknown"`},
+	}
+	for _, tt := range tests {
+		got, err := LoadAuthProfile(path, tt.name)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("LoadAuthProfile(%q) error = %v, want %q", tt.name, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("LoadAuthProfile(%q): %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("LoadAuthProfile(%q) = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := LoadAuthProfile(filepath.Join(t.TempDir(), "missing.json"), ""); err == nil {
+		t.Error("LoadAuthProfile of missing file succeeded")
+	}
+}
+
+func TestAuthProfileFromEnv(t *testing.T) {
+	t.Setenv(EnvGrant, GrantAuthorizationCode)
+	t.Setenv(EnvClientID, "id")
+	t.Setenv(EnvClientSecret, "secret")
+	t.Setenv(EnvScopes, "browse, stash publish")
+	t.Setenv(EnvCallbackURL, "http://localhost:9000/callback")
+	t.Setenv(EnvTokenStorePath, "/tmp/token.json")
+	t.Setenv(EnvTokenStoreKey, "a2V5")
+
+	got, err := AuthProfileFromEnv()
+	if err != nil {
+		t.Fatalf("AuthProfileFromEnv: %v", err)
+	}
+	want := AuthProfile{
+		Grant:          GrantAuthorizationCode,
+		ClientID:       "id",
+		ClientSecret:   "secret",
+		Scopes:         []string{BrowseScope, StashScope, PublishScope},
+		CallbackURL:    "http://localhost:9000/callback",
+		TokenStorePath: "/tmp/token.json",
+		TokenStoreKey:  "a2V5",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AuthProfileFromEnv = %+v, want %+v", got, want)
+	}
+
+	t.Setenv(EnvGrant, "")
+	if _, err := AuthProfileFromEnv(); err == nil || !strings.Contains(err.Error(), "not supported by client_credentials grant") {
+		t.Errorf("AuthProfileFromEnv error = %v, want settings rejected by client_credentials grant", err)
+	}
+}
+
+func TestAuthProfileValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile AuthProfile
+		err     string
+	}{
+		{"client credentials", AuthProfile{ClientID: "id", ClientSecret: "secret"}, ""},
+		{"missing client id", AuthProfile{ClientSecret: "secret"}, "client id is missing"},
+		{"missing client secret", AuthProfile{Grant: GrantAuthorizationCode, ClientID: "id"}, "client secret is missing"},
+		{"unknown grant", AuthProfile{Grant: "password", ClientID: "id", ClientSecret: "secret"}, `unknown grant "password"`},
+		{
+			"client credentials with user settings",
+			AuthProfile{ClientID: "id", ClientSecret: "secret", CallbackURL: "http://localhost/callback", TokenStorePath: "token.json"},
+			"callback url, token store path not supported by client_credentials grant",
+		},
+		{
+			"token store key without path",
+			AuthProfile{Grant: GrantAuthorizationCode, ClientID: "id", ClientSecret: "secret", TokenStoreKey: "a2V5"},
+			"token store key is set without token store path",
+		},
+	}
+	for _, tt := range tests {
+		err := tt.profile.validate()
+		if tt.err == "" {
+			if err != nil {
+				t.Errorf("%s: validate: %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tt.err {
+			t.Errorf("%s: validate error = %v, want %q", tt.name, err, tt.err)
+		}
+		if _, authErr := tt.profile.Authenticator(); authErr == nil {
+			t.Errorf("%s: Authenticator accepted invalid profile", tt.name)
+		}
+	}
+}
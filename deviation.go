@@ -1,7 +1,9 @@
 package deviantart
 
 import (
+	"context"
 	"fmt"
+	"iter"
 
 	"github.com/dghubble/sling"
 	"github.com/google/uuid"
@@ -108,6 +110,10 @@ type Deviation struct {
 	SuggestedReasons []any `json:"suggested_reasons,omitempty"`
 }
 
+func (d Deviation) itemID() any {
+	return d.DeviationID
+}
+
 type PremiumFolderData struct {
 	Type           string    `json:"type"`
 	HasAccess      bool      `json:"has_access"`
@@ -151,11 +157,16 @@ type DeviationUpdateResponse struct {
 //
 //   - browse
 func (s *DeviationService) Deviation(deviationID uuid.UUID) (Deviation, error) {
+	return s.DeviationContext(context.Background(), deviationID)
+}
+
+// DeviationContext is like [DeviationService.Deviation] but uses ctx for the request.
+func (s *DeviationService) DeviationContext(ctx context.Context, deviationID uuid.UUID) (Deviation, error) {
 	var (
 		success Deviation
 		failure Error
 	)
-	_, err := s.sling.New().Get(deviationID.String()).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get(deviationID.String()), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return Deviation{}, fmt.Errorf("unable to fetch deviation: %w", err)
 	}
@@ -182,12 +193,17 @@ type Content struct {
 //
 //   - browse
 func (s *DeviationService) Content(deviationID uuid.UUID) (Content, error) {
+	return s.ContentContext(context.Background(), deviationID)
+}
+
+// ContentContext is like [DeviationService.Content] but uses ctx for the request.
+func (s *DeviationService) ContentContext(ctx context.Context, deviationID uuid.UUID) (Content, error) {
 	var (
 		success Content
 		failure Error
 	)
 	params := &deviationIDParam{DeviationID: deviationID}
-	_, err := s.sling.New().Get("content/").QueryStruct(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("content/").QueryStruct(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return Content{}, fmt.Errorf("unable to fetch deviation content: %w", err)
 	}
@@ -209,11 +225,16 @@ type DownloadResponse struct {
 //
 //   - browse
 func (s *DeviationService) Download(deviationID uuid.UUID) (DownloadResponse, error) {
+	return s.DownloadContext(context.Background(), deviationID)
+}
+
+// DownloadContext is like [DeviationService.Download] but uses ctx for the request.
+func (s *DeviationService) DownloadContext(ctx context.Context, deviationID uuid.UUID) (DownloadResponse, error) {
 	var (
 		success DownloadResponse
 		failure Error
 	)
-	_, err := s.sling.New().Get("download/").Path(deviationID.String()).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("download/").Path(deviationID.String()), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return DownloadResponse{}, fmt.Errorf("unable to fetch download data: %w", err)
 	}
@@ -260,11 +281,16 @@ type EditDeviationParams struct {
 //   - stash
 //   - publish
 func (s *DeviationService) Edit(deviationID uuid.UUID, params *EditDeviationParams) (DeviationUpdateResponse, error) {
+	return s.EditContext(context.Background(), deviationID, params)
+}
+
+// EditContext is like [DeviationService.Edit] but uses ctx for the request.
+func (s *DeviationService) EditContext(ctx context.Context, deviationID uuid.UUID, params *EditDeviationParams) (DeviationUpdateResponse, error) {
 	var (
 		success DeviationUpdateResponse
 		failure Error
 	)
-	_, err := s.sling.New().Get("edit/").Path(deviationID.String()).BodyForm(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("edit/").Path(deviationID.String()).BodyForm(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return DeviationUpdateResponse{}, fmt.Errorf("unable to edit deviation: %w", err)
 	}
@@ -291,17 +317,29 @@ type EmbeddedContentParams struct {
 //
 //   - browse
 func (s *DeviationService) EmbeddedContent(params *EmbeddedContentParams, page *OffsetParams) (OffsetResponse[Deviation], error) {
+	return s.EmbeddedContentContext(context.Background(), params, page)
+}
+
+// EmbeddedContentContext is like [DeviationService.EmbeddedContent] but uses ctx for the request.
+func (s *DeviationService) EmbeddedContentContext(ctx context.Context, params *EmbeddedContentParams, page *OffsetParams) (OffsetResponse[Deviation], error) {
 	var (
 		success OffsetResponse[Deviation]
 		failure Error
 	)
-	_, err := s.sling.New().Get("embeddedcontent/").QueryStruct(params).QueryStruct(page).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("embeddedcontent/").QueryStruct(params).QueryStruct(page), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return OffsetResponse[Deviation]{}, fmt.Errorf("fetch content embedded in a deviation: %w", err)
 	}
 	return success, nil
 }
 
+// EmbeddedContentIter iterates over all pages of [DeviationService.EmbeddedContent].
+func (s *DeviationService) EmbeddedContentIter(ctx context.Context, params *EmbeddedContentParams, opts *IterOptions) iter.Seq2[Deviation, error] {
+	return iterOffset(ctx, opts, iterOrigin("Deviation.EmbeddedContent", params), func(ctx context.Context, page *OffsetParams) (OffsetResponse[Deviation], error) {
+		return s.EmbeddedContentContext(ctx, params, page)
+	})
+}
+
 type DeviationMetadata struct {
 	DeviationID          uuid.UUID            `json:"deviationid"`
 	PrintID              uuid.UUID            `json:"uuid,omitempty"`
@@ -382,11 +420,16 @@ type MetadataParams struct {
 //
 //   - browse
 func (s *DeviationService) Metadata(params *MetadataParams) (MetadataResponse, error) {
+	return s.MetadataContext(context.Background(), params)
+}
+
+// MetadataContext is like [DeviationService.Metadata] but uses ctx for the request.
+func (s *DeviationService) MetadataContext(ctx context.Context, params *MetadataParams) (MetadataResponse, error) {
 	var (
 		success MetadataResponse
 		failure Error
 	)
-	_, err := s.sling.New().Get("metadata").QueryStruct(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("metadata").QueryStruct(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return MetadataResponse{}, fmt.Errorf("unable to fetch deviation metadata: %w", err)
 	}
@@ -407,14 +450,26 @@ type FaveInfo struct {
 //
 //   - browse
 func (s *DeviationService) WhoFaved(deviationID uuid.UUID, page *OffsetParams) (OffsetResponse[FaveInfo], error) {
+	return s.WhoFavedContext(context.Background(), deviationID, page)
+}
+
+// WhoFavedContext is like [DeviationService.WhoFaved] but uses ctx for the request.
+func (s *DeviationService) WhoFavedContext(ctx context.Context, deviationID uuid.UUID, page *OffsetParams) (OffsetResponse[FaveInfo], error) {
 	var (
 		success OffsetResponse[FaveInfo]
 		failure Error
 	)
 	params := &deviationIDParam{DeviationID: deviationID}
-	_, err := s.sling.New().Get("whofaved").QueryStruct(params).QueryStruct(page).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("whofaved").QueryStruct(params).QueryStruct(page), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return OffsetResponse[FaveInfo]{}, fmt.Errorf("unable to fetch whofaved a deviation: %w", err)
 	}
 	return success, nil
 }
+
+// WhoFavedIter iterates over all pages of [DeviationService.WhoFaved].
+func (s *DeviationService) WhoFavedIter(ctx context.Context, deviationID uuid.UUID, opts *IterOptions) iter.Seq2[FaveInfo, error] {
+	return iterOffset(ctx, opts, iterOrigin("Deviation.WhoFaved", deviationID), func(ctx context.Context, page *OffsetParams) (OffsetResponse[FaveInfo], error) {
+		return s.WhoFavedContext(ctx, deviationID, page)
+	})
+}
@@ -1,6 +1,7 @@
 package deviantart
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -41,11 +42,16 @@ type CreateJournalParams struct {
 //
 //   - user.manage
 func (s *DeviationService) CreateJournal(params *CreateJournalParams) (uuid.UUID, error) {
+	return s.CreateJournalContext(context.Background(), params)
+}
+
+// CreateJournalContext is like [DeviationService.CreateJournal] but uses ctx for the request.
+func (s *DeviationService) CreateJournalContext(ctx context.Context, params *CreateJournalParams) (uuid.UUID, error) {
 	var (
 		success map[string]uuid.UUID
 		failure Error
 	)
-	_, err := s.sling.New().Post("journal/create/").BodyForm(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Post("journal/create/").BodyForm(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return uuid.UUID{}, fmt.Errorf("unable to create journal: %w", err)
 	}
@@ -87,11 +93,16 @@ type UpdateJournalParams struct {
 //
 //   - user.manage
 func (s *DeviationService) UpdateJournal(deviationID uuid.UUID, params *UpdateJournalParams) (DeviationUpdateResponse, error) {
+	return s.UpdateJournalContext(context.Background(), deviationID, params)
+}
+
+// UpdateJournalContext is like [DeviationService.UpdateJournal] but uses ctx for the request.
+func (s *DeviationService) UpdateJournalContext(ctx context.Context, deviationID uuid.UUID, params *UpdateJournalParams) (DeviationUpdateResponse, error) {
 	var (
 		success DeviationUpdateResponse
 		failure Error
 	)
-	_, err := s.sling.New().Post("journal/update/").Path(deviationID.String()).BodyForm(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Post("journal/update/").Path(deviationID.String()).BodyForm(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return DeviationUpdateResponse{}, fmt.Errorf("unable to update journal: %w", err)
 	}
@@ -1,6 +1,7 @@
 package deviantart
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -50,11 +51,16 @@ type CreateLiteratureParams struct {
 //
 //   - user.manage
 func (s *DeviationService) CreateLiterature(params *CreateLiteratureParams) (uuid.UUID, error) {
+	return s.CreateLiteratureContext(context.Background(), params)
+}
+
+// CreateLiteratureContext is like [DeviationService.CreateLiterature] but uses ctx for the request.
+func (s *DeviationService) CreateLiteratureContext(ctx context.Context, params *CreateLiteratureParams) (uuid.UUID, error) {
 	var (
 		success map[string]uuid.UUID
 		failure Error
 	)
-	_, err := s.sling.New().Post("literature/create/").BodyForm(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Post("literature/create/").BodyForm(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return uuid.UUID{}, fmt.Errorf("unable to create literature: %w", err)
 	}
@@ -97,11 +103,16 @@ type UpdateLiteratureParams struct {
 //
 //   - user.manage
 func (s *DeviationService) UpdateLiterature(deviationID uuid.UUID, params *UpdateLiteratureParams) (DeviationUpdateResponse, error) {
+	return s.UpdateLiteratureContext(context.Background(), deviationID, params)
+}
+
+// UpdateLiteratureContext is like [DeviationService.UpdateLiterature] but uses ctx for the request.
+func (s *DeviationService) UpdateLiteratureContext(ctx context.Context, deviationID uuid.UUID, params *UpdateLiteratureParams) (DeviationUpdateResponse, error) {
 	var (
 		success DeviationUpdateResponse
 		failure Error
 	)
-	_, err := s.sling.New().Post("literature/update/").Path(deviationID.String()).BodyForm(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Post("literature/update/").Path(deviationID.String()).BodyForm(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return DeviationUpdateResponse{}, fmt.Errorf("unable to update literature: %w", err)
 	}
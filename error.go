@@ -1,6 +1,40 @@
 package deviantart
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/leonidboykov/go-deviantart/internal/ratelimit"
+)
+
+// Error kinds to match an [Error] with [errors.Is].
+var (
+	// ErrInvalidRequest matches validation errors, see [Error.Details] for
+	// invalid fields.
+	ErrInvalidRequest = errors.New("invalid request")
+
+	// ErrInvalidToken matches errors caused by an expired or revoked token.
+	ErrInvalidToken = errors.New("invalid token")
+
+	// ErrInsufficientScope matches errors caused by a token that lacks scopes
+	// required by the endpoint.
+	ErrInsufficientScope = errors.New("insufficient scope")
+
+	// ErrNotFound matches errors of missing resources.
+	ErrNotFound = errors.New("not found")
+
+	// ErrServer matches DeviantArt internal errors.
+	ErrServer = errors.New("server error")
+)
+
+// ErrRateLimited is returned when a request is still throttled after all the
+// retries allowed by [RetryPolicy]. It also matches an [Error] with 429 Too
+// Many Requests status.
+var ErrRateLimited = ratelimit.ErrMaxRetries
 
 type Error struct {
 	StatusResponse
@@ -16,17 +50,57 @@ type Error struct {
 
 	// An additional endpoint specific error code.
 	Code int `json:"error_code"`
+
+	// HTTP status code of the response.
+	StatusCode int `json:"-"`
+
+	// HTTP headers of the response.
+	Header http.Header `json:"-"`
 }
 
 func (e Error) Error() string {
-	// TODO: Append details.
-	return fmt.Sprintf("%s: %s", e.Type, e.Description)
+	var b strings.Builder
+	if e.Type != "" {
+		fmt.Fprintf(&b, "%s: %s", e.Type, e.Description)
+	} else {
+		fmt.Fprintf(&b, "unexpected status: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	if e.Code != 0 {
+		fmt.Fprintf(&b, " [code %d]", e.Code)
+	}
+	if len(e.Details) > 0 {
+		details := make([]string, 0, len(e.Details))
+		for _, key := range slices.Sorted(maps.Keys(e.Details)) {
+			details = append(details, key+": "+e.Details[key])
+		}
+		fmt.Fprintf(&b, " (%s)", strings.Join(details, ", "))
+	}
+	return b.String()
+}
+
+// Is reports whether the error is of the target kind.
+func (e Error) Is(target error) bool {
+	switch target {
+	case ErrInvalidRequest:
+		return e.Type == "invalid_request" || e.StatusCode == http.StatusBadRequest
+	case ErrInvalidToken:
+		return e.Type == "invalid_token" || e.StatusCode == http.StatusUnauthorized
+	case ErrInsufficientScope:
+		return e.Type == "insufficient_scope"
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrServer:
+		return e.Type == "server_error" || e.StatusCode >= http.StatusInternalServerError
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
 }
 
 func relevantError(httpError error, apiError Error) error {
 	if httpError != nil {
 		return httpError
-	} else if apiError.Type != "" {
+	} else if apiError.Type != "" || apiError.StatusCode != 0 {
 		return apiError
 	}
 	return nil
@@ -1,7 +1,10 @@
 package deviantart
 
 import (
+	"context"
 	"fmt"
+	"iter"
+	"reflect"
 
 	"github.com/dghubble/sling"
 	"github.com/google/uuid"
@@ -66,17 +69,30 @@ type FolderContent struct {
 //
 //   - browse
 func (s *FoldersService[T]) Folder(folderID uuid.UUID, params *FolderParams, page *OffsetParams) (FolderContent, error) {
+	return s.FolderContext(context.Background(), folderID, params, page)
+}
+
+// FolderContext is like [FoldersService.Folder] but uses ctx for the request.
+func (s *FoldersService[T]) FolderContext(ctx context.Context, folderID uuid.UUID, params *FolderParams, page *OffsetParams) (FolderContent, error) {
 	var (
 		success FolderContent
 		failure Error
 	)
-	_, err := s.sling.New().Get(folderID.String()).QueryStruct(params).QueryStruct(page).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get(folderID.String()).QueryStruct(params).QueryStruct(page), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return FolderContent{}, fmt.Errorf("unable to fetch folder: %w", err)
 	}
 	return success, nil
 }
 
+// FolderIter iterates over all pages of [FoldersService.Folder].
+func (s *FoldersService[T]) FolderIter(ctx context.Context, folderID uuid.UUID, params *FolderParams, opts *IterOptions) iter.Seq2[Deviation, error] {
+	return iterOffset(ctx, opts, iterOrigin(reflect.TypeFor[T]().Name()+".Folder", folderID, params), func(ctx context.Context, page *OffsetParams) (OffsetResponse[Deviation], error) {
+		content, err := s.FolderContext(ctx, folderID, params, page)
+		return content.OffsetResponse, err
+	})
+}
+
 type usernameParams struct {
 	Username string `url:"username,omitempty"`
 }
@@ -90,18 +106,30 @@ type usernameParams struct {
 //
 //   - browse
 func (s *FoldersService[T]) All(username string, page *OffsetParams) (OffsetResponse[Deviation], error) {
+	return s.AllContext(context.Background(), username, page)
+}
+
+// AllContext is like [FoldersService.All] but uses ctx for the request.
+func (s *FoldersService[T]) AllContext(ctx context.Context, username string, page *OffsetParams) (OffsetResponse[Deviation], error) {
 	var (
 		success OffsetResponse[Deviation]
 		failure Error
 	)
 	params := &usernameParams{Username: username}
-	_, err := s.sling.New().Get("all").QueryStruct(params).QueryStruct(page).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("all").QueryStruct(params).QueryStruct(page), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return OffsetResponse[Deviation]{}, fmt.Errorf("unable to fetch all content: %w", err)
 	}
 	return success, nil
 }
 
+// AllIter iterates over all pages of [FoldersService.All].
+func (s *FoldersService[T]) AllIter(ctx context.Context, username string, opts *IterOptions) iter.Seq2[Deviation, error] {
+	return iterOffset(ctx, opts, iterOrigin(reflect.TypeFor[T]().Name()+".All", username), func(ctx context.Context, page *OffsetParams) (OffsetResponse[Deviation], error) {
+		return s.AllContext(ctx, username, page)
+	})
+}
+
 // Folders fetches collection folders.
 //
 // To connect to this endpoint OAuth2 Access Token from the Client Credentials
@@ -113,17 +141,29 @@ func (s *FoldersService[T]) All(username string, page *OffsetParams) (OffsetResp
 //
 // TODO: Support `ext_preload` for collections.
 func (s *FoldersService[T]) Folders(params *FoldersParams, page *OffsetParams) (OffsetResponse[T], error) {
+	return s.FoldersContext(context.Background(), params, page)
+}
+
+// FoldersContext is like [FoldersService.Folders] but uses ctx for the request.
+func (s *FoldersService[T]) FoldersContext(ctx context.Context, params *FoldersParams, page *OffsetParams) (OffsetResponse[T], error) {
 	var (
 		success OffsetResponse[T]
 		failure Error
 	)
-	_, err := s.sling.New().Get("folders").QueryStruct(params).QueryStruct(page).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("folders").QueryStruct(params).QueryStruct(page), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return OffsetResponse[T]{}, fmt.Errorf("unable to fetch folders: %w", err)
 	}
 	return success, nil
 }
 
+// FoldersIter iterates over all pages of [FoldersService.Folders].
+func (s *FoldersService[T]) FoldersIter(ctx context.Context, params *FoldersParams, opts *IterOptions) iter.Seq2[T, error] {
+	return iterOffset(ctx, opts, iterOrigin(reflect.TypeFor[T]().Name()+".Folders", params), func(ctx context.Context, page *OffsetParams) (OffsetResponse[T], error) {
+		return s.FoldersContext(ctx, params, page)
+	})
+}
+
 type CopyDeviationsParams struct {
 	TargetFolderID uuid.UUID   `url:"target_folderid,omitempty"`
 	DeviationIDs   []uuid.UUID `url:"deviationids,omitempty"`
@@ -139,10 +179,15 @@ type CopyDeviationsParams struct {
 //   - browse
 //   - collection or gallery
 func (s *FoldersService[T]) CopyDeviations(params *CopyDeviationsParams) error {
+	return s.CopyDeviationsContext(context.Background(), params)
+}
+
+// CopyDeviationsContext is like [FoldersService.CopyDeviations] but uses ctx for the request.
+func (s *FoldersService[T]) CopyDeviationsContext(ctx context.Context, params *CopyDeviationsParams) error {
 	var (
 		failure Error
 	)
-	_, err := s.sling.New().Get("folders/copy_deviations").QueryStruct(params).Receive(nil, &failure)
+	_, err := receive(ctx, s.sling.New().Get("folders/copy_deviations").QueryStruct(params), nil, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return fmt.Errorf("unable to copy deviations: %w", err)
 	}
@@ -172,11 +217,16 @@ type CreateFolderParams struct {
 //   - browse
 //   - collection or gallery
 func (s *FoldersService[T]) Create(params *CreateFolderParams) (Folder, error) {
+	return s.CreateContext(context.Background(), params)
+}
+
+// CreateContext is like [FoldersService.Create] but uses ctx for the request.
+func (s *FoldersService[T]) CreateContext(ctx context.Context, params *CreateFolderParams) (Folder, error) {
 	var (
 		success Folder
 		failure Error
 	)
-	_, err := s.sling.New().Post("folders/create").BodyForm(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Post("folders/create").BodyForm(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return Folder{}, fmt.Errorf("unable to create folder: %w", err)
 	}
@@ -204,10 +254,15 @@ type MoveDeviationsParams struct {
 //   - browse
 //   - collection or gallery
 func (s *FoldersService[T]) MoveDeviations(params *MoveDeviationsParams) error {
+	return s.MoveDeviationsContext(context.Background(), params)
+}
+
+// MoveDeviationsContext is like [FoldersService.MoveDeviations] but uses ctx for the request.
+func (s *FoldersService[T]) MoveDeviationsContext(ctx context.Context, params *MoveDeviationsParams) error {
 	var (
 		failure Error
 	)
-	_, err := s.sling.New().Post("folders/move_destination").BodyForm(params).Receive(nil, &failure)
+	_, err := receive(ctx, s.sling.New().Post("folders/move_destination").BodyForm(params), nil, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return fmt.Errorf("unable to move deviations: %w", err)
 	}
@@ -224,10 +279,15 @@ func (s *FoldersService[T]) MoveDeviations(params *MoveDeviationsParams) error {
 //   - browse
 //   - collection or gallery
 func (s *FoldersService[T]) Remove(folderID uuid.UUID) error {
+	return s.RemoveContext(context.Background(), folderID)
+}
+
+// RemoveContext is like [FoldersService.Remove] but uses ctx for the request.
+func (s *FoldersService[T]) RemoveContext(ctx context.Context, folderID uuid.UUID) error {
 	var (
 		failure Error
 	)
-	_, err := s.sling.New().Get("folders/remove/").Path(folderID.String()).Receive(nil, &failure)
+	_, err := receive(ctx, s.sling.New().Get("folders/remove/").Path(folderID.String()), nil, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return fmt.Errorf("unable to remove folder: %w", err)
 	}
@@ -252,10 +312,15 @@ type RemoveDeviationsParams struct {
 //   - browse
 //   - collection or gallery
 func (s *FoldersService[T]) RemoveDeviations(params *RemoveDeviationsParams) error {
+	return s.RemoveDeviationsContext(context.Background(), params)
+}
+
+// RemoveDeviationsContext is like [FoldersService.RemoveDeviations] but uses ctx for the request.
+func (s *FoldersService[T]) RemoveDeviationsContext(ctx context.Context, params *RemoveDeviationsParams) error {
 	var (
 		failure Error
 	)
-	_, err := s.sling.New().Post("folders/remove_deviations").BodyForm(params).Receive(nil, &failure)
+	_, err := receive(ctx, s.sling.New().Post("folders/remove_deviations").BodyForm(params), nil, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return fmt.Errorf("unable to remove deviations: %w", err)
 	}
@@ -286,10 +351,15 @@ type UpdateFoldersParams struct {
 //   - browse
 //   - collection or gallery
 func (s *FoldersService[T]) Update(params *UpdateFoldersParams) error {
+	return s.UpdateContext(context.Background(), params)
+}
+
+// UpdateContext is like [FoldersService.Update] but uses ctx for the request.
+func (s *FoldersService[T]) UpdateContext(ctx context.Context, params *UpdateFoldersParams) error {
 	var (
 		failure Error
 	)
-	_, err := s.sling.New().Get("folders/update").QueryStruct(params).Receive(nil, &failure)
+	_, err := receive(ctx, s.sling.New().Get("folders/update").QueryStruct(params), nil, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return fmt.Errorf("unable to update folders: %w", err)
 	}
@@ -317,10 +387,15 @@ type UpdateDeviationOrderParams struct {
 //   - browse
 //   - collection or gallery
 func (s *FoldersService[T]) UpdateDeviationOrder(params *UpdateDeviationOrderParams) error {
+	return s.UpdateDeviationOrderContext(context.Background(), params)
+}
+
+// UpdateDeviationOrderContext is like [FoldersService.UpdateDeviationOrder] but uses ctx for the request.
+func (s *FoldersService[T]) UpdateDeviationOrderContext(ctx context.Context, params *UpdateDeviationOrderParams) error {
 	var (
 		failure Error
 	)
-	_, err := s.sling.New().Post("folders/update_deviation_order").BodyForm(params).Receive(nil, &failure)
+	_, err := receive(ctx, s.sling.New().Post("folders/update_deviation_order").BodyForm(params), nil, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return fmt.Errorf("unable to update deviations order: %w", err)
 	}
@@ -345,6 +420,11 @@ type UpdateOrderParams struct {
 //   - browse
 //   - collection or gallery
 func (s *FoldersService[T]) UpdateOrder(folderID uuid.UUID, position int) error {
+	return s.UpdateOrderContext(context.Background(), folderID, position)
+}
+
+// UpdateOrderContext is like [FoldersService.UpdateOrder] but uses ctx for the request.
+func (s *FoldersService[T]) UpdateOrderContext(ctx context.Context, folderID uuid.UUID, position int) error {
 	type updateOrderParams struct {
 		FolderID string `url:"folderid"`
 		Position int    `url:"position"`
@@ -353,7 +433,7 @@ func (s *FoldersService[T]) UpdateOrder(folderID uuid.UUID, position int) error
 		failure Error
 	)
 	params := &updateOrderParams{FolderID: folderID.String(), Position: position}
-	_, err := s.sling.New().Post("folders/update_order").BodyForm(params).Receive(nil, &failure)
+	_, err := receive(ctx, s.sling.New().Post("folders/update_order").BodyForm(params), nil, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return fmt.Errorf("unable to update folders order: %w", err)
 	}
@@ -0,0 +1,96 @@
+package authserver
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2/authhandler"
+)
+
+// StdinPrompt returns a prompt that prints the authorization URL to out and
+// reads a single line from in. Input after the line is left unread in in.
+func StdinPrompt(in io.Reader, out io.Writer, allowBareCode bool) func(authCodeURL string) (string, error) {
+	return func(authCodeURL string) (string, error) {
+		fmt.Fprintln(out, "Visit the URL for the auth dialog:", authCodeURL)
+		if allowBareCode {
+			fmt.Fprint(out, "Paste the redirect URL or the code: ")
+		} else {
+			fmt.Fprint(out, "Paste the redirect URL: ")
+		}
+		line, err := readLine(in)
+		if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+			return "", fmt.Errorf("read authorization response: %w", err)
+		}
+		return line, nil
+	}
+}
+
+// readLine reads from r up to and including the first newline. It reads a
+// byte at a time instead of buffering, so input following the line is not
+// consumed.
+func readLine(r io.Reader) (string, error) {
+	var (
+		line strings.Builder
+		b    [1]byte
+	)
+	for {
+		n, err := r.Read(b[:])
+		if n > 0 {
+			line.WriteByte(b[0])
+			if b[0] == '\n' {
+				return line.String(), nil
+			}
+		}
+		if err != nil {
+			return line.String(), err
+		}
+	}
+}
+
+// PromptHandler returns a handler that asks the user for the redirect URL
+// instead of receiving a callback. The redirect URL carries the state to
+// validate. If allowBareCode is true, the user may paste the authorization
+// code alone; the state cannot be validated then, so the handler returns the
+// expected one.
+func PromptHandler(prompt func(authCodeURL string) (string, error), allowBareCode bool) authhandler.AuthorizationHandler {
+	return func(authCodeURL string) (code string, state string, err error) {
+		input, err := prompt(authCodeURL)
+		if err != nil {
+			return "", "", err
+		}
+		input = strings.TrimSpace(input)
+		if input == "" {
+			return "", "", errors.New("empty authorization response")
+		}
+
+		uri, err := url.Parse(input)
+		if err != nil || uri.RawQuery == "" {
+			if !allowBareCode {
+				return "", "", errors.New("authorization response is not a redirect url")
+			}
+			return input, expectedState(authCodeURL), nil
+		}
+		query := uri.Query()
+		if query.Get("error") != "" {
+			return "", "", &CallbackError{
+				Code:        query.Get("error"),
+				Description: query.Get("error_description"),
+			}
+		}
+		if query.Get("code") == "" {
+			return "", "", errors.New("redirect url without authorization code")
+		}
+		return query.Get("code"), query.Get("state"), nil
+	}
+}
+
+func expectedState(authCodeURL string) string {
+	uri, err := url.Parse(authCodeURL)
+	if err != nil {
+		return ""
+	}
+	return uri.Query().Get("state")
+}
@@ -0,0 +1,72 @@
+package authserver
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+const testAuthCodeURL = "https://example.com/authorize?client_id=client&state=expected"
+
+func paste(input string) func(string) (string, error) {
+	return func(string) (string, error) {
+		return input, nil
+	}
+}
+
+func TestPromptHandlerRedirectURL(t *testing.T) {
+	handler := PromptHandler(paste(" http://localhost:8080/callback?code=secret&state=forged\n"), false)
+	code, state, err := handler(testAuthCodeURL)
+	if err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+	if code != "secret" || state != "forged" {
+		t.Errorf("code, state = %q, %q, want %q, %q", code, state, "secret", "forged")
+	}
+}
+
+func TestPromptHandlerRejectsBareCode(t *testing.T) {
+	handler := PromptHandler(paste("attackercode"), false)
+	code, state, err := handler(testAuthCodeURL)
+	if err == nil {
+		t.Fatalf("handler accepted a bare code: code %q, state %q", code, state)
+	}
+}
+
+func TestPromptHandlerAllowsBareCode(t *testing.T) {
+	handler := PromptHandler(paste("code"), true)
+	code, state, err := handler(testAuthCodeURL)
+	if err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+	if code != "code" || state != "expected" {
+		t.Errorf("code, state = %q, %q, want %q, %q", code, state, "code", "expected")
+	}
+}
+
+func TestPromptHandlerCallbackError(t *testing.T) {
+	handler := PromptHandler(paste("http://localhost:8080/callback?error=access_denied"), false)
+	_, _, err := handler(testAuthCodeURL)
+	var callbackErr *CallbackError
+	if !errors.As(err, &callbackErr) || callbackErr.Code != "access_denied" {
+		t.Errorf("handler error = %v, want access_denied callback error", err)
+	}
+}
+
+func TestStdinPromptLeavesInputUnread(t *testing.T) {
+	in := strings.NewReader("http://localhost/callback?code=first\nsecond\nrest")
+	prompt := StdinPrompt(in, io.Discard, false)
+	for _, want := range []string{"http://localhost/callback?code=first\n", "second\n", "rest"} {
+		line, err := prompt(testAuthCodeURL)
+		if err != nil {
+			t.Fatalf("prompt: %v", err)
+		}
+		if line != want {
+			t.Errorf("line = %q, want %q", line, want)
+		}
+	}
+	if _, err := prompt(testAuthCodeURL); !errors.Is(err, io.EOF) {
+		t.Errorf("prompt error = %v, want %v", err, io.EOF)
+	}
+}
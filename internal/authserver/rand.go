@@ -1,13 +1,27 @@
 package authserver
 
-import "math/rand"
+import "crypto/rand"
 
 const letterBytes = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
 
+// RandString returns a cryptographically random string of n letters.
 func RandString(n int) string {
-	b := make([]byte, n)
-	for i := range b {
-		b[i] = letterBytes[rand.Intn(len(letterBytes))]
+	// Discard bytes beyond the largest multiple of the alphabet size to keep
+	// letters uniformly distributed.
+	const limit = 256 - 256%len(letterBytes)
+
+	b := make([]byte, 0, n)
+	buf := make([]byte, n)
+	for len(b) < n {
+		if _, err := rand.Read(buf); err != nil {
+			// crypto/rand never fails on supported platforms.
+			panic(err)
+		}
+		for _, c := range buf {
+			if int(c) < limit && len(b) < n {
+				b = append(b, letterBytes[int(c)%len(letterBytes)])
+			}
+		}
 	}
 	return string(b)
 }
@@ -0,0 +1,23 @@
+package authserver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRandString(t *testing.T) {
+	for _, n := range []int{0, 1, 32, 100} {
+		s := RandString(n)
+		if len(s) != n {
+			t.Errorf("len(RandString(%d)) = %d", n, len(s))
+		}
+		if i := strings.IndexFunc(s, func(r rune) bool {
+			return !strings.ContainsRune(letterBytes, r)
+		}); i >= 0 {
+			t.Errorf("RandString(%d) = %q contains %q outside the alphabet", n, s, s[i])
+		}
+	}
+	if RandString(32) == RandString(32) {
+		t.Error("RandString returned the same string twice")
+	}
+}
@@ -1,46 +1,166 @@
 package authserver
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"os"
+	"sync"
+	"time"
 
 	"golang.org/x/oauth2/authhandler"
 )
 
-func AuthHandler(callbackURL string) authhandler.AuthorizationHandler {
-	return func(authCodeURL string) (code string, state string, err error) {
-		fmt.Println("Visit the URL for the auth dialog:", authCodeURL)
+const (
+	// DefaultTimeout is used when no timeout is set in [Options].
+	DefaultTimeout = 5 * time.Minute
+
+	// DefaultSuccessPage closes the browser tab after successful authorization.
+	DefaultSuccessPage = `<!DOCTYPE html><html><body><p>Authorization succeeded. You can close this tab.</p><script>window.close();</script></body></html>`
+
+	// DefaultFailurePage is served when authorization fails.
+	DefaultFailurePage = `<!DOCTYPE html><html><body><p>Authorization failed. You can close this tab.</p></body></html>`
+)
+
+// ErrTimeout is returned when no callback is received in time.
+var ErrTimeout = errors.New("authorization callback timed out")
+
+// CallbackError is an OAuth2 error response received in the callback, such
+// as access_denied.
+type CallbackError struct {
+	Code        string
+	Description string
+}
+
+func (e *CallbackError) Error() string {
+	if e.Description == "" {
+		return "authorization failed: " + e.Code
+	}
+	return "authorization failed: " + e.Code + ": " + e.Description
+}
+
+// Options configures the callback server.
+type Options struct {
+	// Timeout limits the time to wait for the callback.
+	Timeout time.Duration
+
+	// SuccessPage is HTML served after successful authorization.
+	SuccessPage string
+
+	// FailurePage is HTML served after failed authorization.
+	FailurePage string
+
+	// Output receives the authorization URL to visit. Default is stdout.
+	Output io.Writer
+}
+
+type result struct {
+	code  string
+	state string
+	err   error
+}
 
+// AuthHandler returns a handler that receives the authorization code on a
+// local server listening on callbackURL. The server accepts a single callback
+// and shuts down afterwards.
+func AuthHandler(callbackURL string, opts Options) authhandler.AuthorizationHandler {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.SuccessPage == "" {
+		opts.SuccessPage = DefaultSuccessPage
+	}
+	if opts.FailurePage == "" {
+		opts.FailurePage = DefaultFailurePage
+	}
+	if opts.Output == nil {
+		opts.Output = os.Stdout
+	}
+
+	return func(authCodeURL string) (code string, state string, err error) {
 		uri, err := url.Parse(callbackURL)
 		if err != nil {
 			return "", "", fmt.Errorf("parse callback url: %w", err)
 		}
+		path := uri.Path
+		if path == "" {
+			path = "/"
+		}
 
-		codeChan := make(chan string)
-		stateChan := make(chan string)
+		// Bind first, so the user is never sent to a callback that cannot be
+		// received.
+		ln, err := net.Listen("tcp", uri.Host)
+		if err != nil {
+			return "", "", fmt.Errorf("listen on callback address: %w", err)
+		}
+
+		results := make(chan result, 1)
+		var once sync.Once
+		done := func(res result) {
+			once.Do(func() { results <- res })
+		}
 
 		mux := http.NewServeMux()
-		mux.HandleFunc(uri.Path, func(w http.ResponseWriter, r *http.Request) {
-			// TODO: Cleanup code.
-			log.Println("Code:", r.FormValue("code"), "State:", r.FormValue("state"))
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			res := result{code: r.FormValue("code"), state: r.FormValue("state")}
+			if r.FormValue("error") != "" {
+				res.err = &CallbackError{
+					Code:        r.FormValue("error"),
+					Description: r.FormValue("error_description"),
+				}
+			} else if res.code == "" {
+				// Not a callback, e.g. /favicon.ico requested by the browser
+				// when the callback path is "/".
+				http.NotFound(w, r)
+				return
+			}
 
-			// Auto close a new tab.
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte(`<script>window.close();</script>`))
+			page := opts.SuccessPage
+			if res.err != nil {
+				page = opts.FailurePage
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			io.WriteString(w, page)
 
-			codeChan <- r.FormValue("code")
-			stateChan <- r.FormValue("state")
+			// Only the first callback counts, repeated ones just get the page.
+			done(res)
 		})
 
+		srv := &http.Server{
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
 		go func() {
-			if err := http.ListenAndServe(uri.Host, mux); err != nil {
-				log.Fatalln(err)
+			if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				done(result{err: fmt.Errorf("serve callback: %w", err)})
 			}
 		}()
+		defer func() {
+			// Shut down in the background, so pending pages are delivered
+			// without holding the flow until connections opened by the
+			// browser in advance time out.
+			go func() {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				if err := srv.Shutdown(ctx); err != nil {
+					srv.Close()
+				}
+			}()
+		}()
 
-		// TODO: Stop http server.
-		return <-codeChan, <-stateChan, err
+		fmt.Fprintln(opts.Output, "Visit the URL for the auth dialog:", authCodeURL)
+
+		timer := time.NewTimer(opts.Timeout)
+		defer timer.Stop()
+		select {
+		case res := <-results:
+			return res.code, res.state, res.err
+		case <-timer.C:
+			return "", "", ErrTimeout
+		}
 	}
 }
@@ -0,0 +1,98 @@
+package authserver
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// notifyWriter signals once the handler prints the authorization URL, i.e.
+// the callback server is listening.
+type notifyWriter chan struct{}
+
+func (w notifyWriter) Write(p []byte) (int, error) {
+	select {
+	case w <- struct{}{}:
+	default:
+	}
+	return len(p), nil
+}
+
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	return ln.Addr().String()
+}
+
+type handlerResult struct {
+	code, state string
+	err         error
+}
+
+// startHandler runs the handler for a callback on the root path and waits for
+// the server to listen.
+func startHandler(t *testing.T) (string, <-chan handlerResult) {
+	t.Helper()
+	base := "http://" + freeAddr(t)
+	ready := make(notifyWriter, 1)
+	handler := AuthHandler(base+"/", Options{Timeout: 5 * time.Second, Output: ready})
+
+	results := make(chan handlerResult, 1)
+	go func() {
+		code, state, err := handler("https://example.com/authorize")
+		results <- handlerResult{code, state, err}
+	}()
+	select {
+	case <-ready:
+	case res := <-results:
+		t.Fatalf("handler failed to start: %v", res.err)
+	}
+	return base, results
+}
+
+func get(t *testing.T, url string) int {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestAuthHandlerIgnoresStrayRequests(t *testing.T) {
+	base, results := startHandler(t)
+
+	if status := get(t, base+"/favicon.ico"); status != http.StatusNotFound {
+		t.Errorf("stray request status = %d, want %d", status, http.StatusNotFound)
+	}
+	if status := get(t, base+"/?code=secret&state=xyz"); status != http.StatusOK {
+		t.Errorf("callback status = %d, want %d", status, http.StatusOK)
+	}
+
+	res := <-results
+	if res.err != nil {
+		t.Fatalf("handler: %v", res.err)
+	}
+	if res.code != "secret" || res.state != "xyz" {
+		t.Errorf("code, state = %q, %q, want %q, %q", res.code, res.state, "secret", "xyz")
+	}
+}
+
+func TestAuthHandlerCallbackError(t *testing.T) {
+	base, results := startHandler(t)
+
+	get(t, base+"/?error=access_denied&error_description=denied")
+
+	res := <-results
+	var callbackErr *CallbackError
+	if !errors.As(res.err, &callbackErr) || callbackErr.Code != "access_denied" {
+		t.Errorf("handler error = %v, want access_denied callback error", res.err)
+	}
+}
@@ -2,41 +2,250 @@ package ratelimit
 
 import (
 	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
 	"net/http"
+	"strconv"
+	"sync/atomic"
 	"time"
 )
 
-const (
-	defaultMaxRetries     = 100
-	defaultBackoffTimeout = 500 * time.Millisecond
-)
-
-var errMaxRetries = errors.New("max retries exceeded")
+// ErrMaxRetries is returned when a request is still throttled after all the
+// attempts allowed by the policy.
+var ErrMaxRetries = errors.New("max retries exceeded")
 
 type Doer interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
+// Policy defines when and how failed requests are retried.
+type Policy struct {
+	// MaxAttempts limits the number of attempts per request, including the
+	// first one. Values less than 2 disable retries.
+	MaxAttempts int
+
+	// MaxWait limits the total time spent waiting between attempts of a single
+	// request. Zero means no limit.
+	MaxWait time.Duration
+
+	// BaseDelay is the delay before the first retry. It doubles with every
+	// subsequent retry.
+	BaseDelay time.Duration
+
+	// MaxDelay caps a single backoff delay. Zero means no cap.
+	MaxDelay time.Duration
+
+	// Jitter randomly shortens every backoff delay by up to the given fraction
+	// in range [0, 1] to spread retries of concurrent requests.
+	Jitter float64
+
+	// RespectRetryAfter makes the client wait for the duration requested by
+	// the Retry-After header instead of the computed backoff.
+	RespectRetryAfter bool
+
+	// RetryServerErrors enables retries of 5xx responses and network errors.
+	// Only idempotent requests (GET, HEAD and OPTIONS) are retried this way.
+	RetryServerErrors bool
+}
+
+// DefaultPolicy returns the policy used when no policy is configured.
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts:       5,
+		MaxWait:           2 * time.Minute,
+		BaseDelay:         500 * time.Millisecond,
+		MaxDelay:          30 * time.Second,
+		Jitter:            0.2,
+		RespectRetryAfter: true,
+		RetryServerErrors: true,
+	}
+}
+
+// backoff returns the delay before the given retry, starting from 1.
+func (p Policy) backoff(retry int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < retry && delay < math.MaxInt64/2; i++ {
+		delay *= 2
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * p.Jitter * float64(delay))
+	}
+	return delay
+}
+
+// Stats holds retry statistics of a client.
+type Stats struct {
+	// Requests is a number of requests sent by callers.
+	Requests uint64
+
+	// Attempts is a number of attempts made, including retries.
+	Attempts uint64
+
+	// Retries is a number of retried attempts.
+	Retries uint64
+
+	// Throttled is a number of 429 Too Many Requests responses received.
+	Throttled uint64
+
+	// Exhausted is a number of requests that failed after all the retries.
+	Exhausted uint64
+
+	// Waited is a total time spent waiting between attempts.
+	Waited time.Duration
+}
+
 type HTTPClient struct {
 	client Doer
+	policy Policy
+
+	// Permanent reports errors that are never retried, such as failures to
+	// authorize the request. It may be nil.
+	Permanent func(err error) bool
+
+	requests  atomic.Uint64
+	attempts  atomic.Uint64
+	retries   atomic.Uint64
+	throttled atomic.Uint64
+	exhausted atomic.Uint64
+	waited    atomic.Int64
+}
+
+func NewHTTPClient(client Doer, policy Policy) *HTTPClient {
+	return &HTTPClient{client: client, policy: policy}
 }
 
-func NewHTTPClient(client Doer) *HTTPClient {
-	return &HTTPClient{client: client}
+// Stats returns a snapshot of retry statistics.
+func (c *HTTPClient) Stats() Stats {
+	return Stats{
+		Requests:  c.requests.Load(),
+		Attempts:  c.attempts.Load(),
+		Retries:   c.retries.Load(),
+		Throttled: c.throttled.Load(),
+		Exhausted: c.exhausted.Load(),
+		Waited:    time.Duration(c.waited.Load()),
+	}
 }
 
+// Do sends the request and retries it according to the policy. Waiting
+// between attempts is interrupted as soon as the request context is done.
 func (c *HTTPClient) Do(req *http.Request) (*http.Response, error) {
-	backoffTimeout := defaultBackoffTimeout
-	for i := 0; i < defaultMaxRetries; i++ {
+	ctx := req.Context()
+	c.requests.Add(1)
+
+	var waited time.Duration
+	for attempt := 1; ; attempt++ {
+		c.attempts.Add(1)
 		resp, err := c.client.Do(req)
-		if err != nil {
+		throttled := err == nil && resp.StatusCode == http.StatusTooManyRequests
+		if throttled {
+			c.throttled.Add(1)
+		}
+		if !c.retryable(req, resp, err) {
 			return resp, err
 		}
-		if resp.StatusCode != http.StatusTooManyRequests {
+
+		delay := c.policy.backoff(attempt)
+		if throttled && c.policy.RespectRetryAfter {
+			if d, ok := retryAfter(resp); ok {
+				delay = d
+			}
+		}
+		if attempt >= c.policy.MaxAttempts || c.policy.MaxWait > 0 && waited+delay > c.policy.MaxWait {
+			c.exhausted.Add(1)
+			if throttled {
+				resp.Body.Close()
+				return nil, ErrMaxRetries
+			}
 			return resp, err
 		}
-		time.Sleep(backoffTimeout)
-		backoffTimeout *= 2
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		waited += delay
+		c.waited.Add(int64(delay))
+		c.retries.Add(1)
+
+		if req, err = rewind(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// rewind returns a copy of the request with a fresh body, since the body of
+// the previous attempt has already been consumed.
+func rewind(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("rewind request body: %w", err)
+	}
+	req = req.Clone(req.Context())
+	req.Body = body
+	return req, nil
+}
+
+// replayable reports whether the request can be sent again.
+func replayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// retryable reports whether the attempt result is worth retrying.
+func (c *HTTPClient) retryable(req *http.Request, resp *http.Response, err error) bool {
+	if c.policy.MaxAttempts < 2 || !replayable(req) {
+		return false
+	}
+	if err != nil {
+		if c.Permanent != nil && c.Permanent(err) {
+			return false
+		}
+		return c.policy.RetryServerErrors && idempotent(req) && req.Context().Err() == nil
+	}
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	case resp.StatusCode >= 500:
+		return c.policy.RetryServerErrors && idempotent(req)
+	}
+	return false
+}
+
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// retryAfter parses Retry-After header in either delay-seconds or HTTP-date
+// form.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
 	}
-	return nil, errMaxRetries
+	return 0, false
 }
@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRewind(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "https://example.com/", strings.NewReader("a=1&b=2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Consume the body like a failed attempt does.
+	io.ReadAll(req.Body)
+
+	rewound, err := rewind(req)
+	if err != nil {
+		t.Fatalf("rewind: %v", err)
+	}
+	if rewound == req {
+		t.Error("rewind returned the same request")
+	}
+	body, _ := io.ReadAll(rewound.Body)
+	if string(body) != "a=1&b=2" {
+		t.Errorf("body = %q, want %q", body, "a=1&b=2")
+	}
+}
+
+func TestRewindWithoutBody(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://example.com/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rewound, err := rewind(req)
+	if err != nil {
+		t.Fatalf("rewind: %v", err)
+	}
+	if rewound != req {
+		t.Error("rewind copied a request without body")
+	}
+}
+
+func TestReplayable(t *testing.T) {
+	newRequest := func(body io.Reader) *http.Request {
+		req, err := http.NewRequest(http.MethodPost, "https://example.com/", body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return req
+	}
+	streamed := newRequest(io.NopCloser(strings.NewReader("data")))
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		want bool
+	}{
+		{"no body", newRequest(nil), true},
+		{"no body sentinel", newRequest(http.NoBody), true},
+		{"buffered body", newRequest(strings.NewReader("data")), true},
+		{"streamed body", streamed, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replayable(tt.req); got != tt.want {
+				t.Errorf("replayable = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
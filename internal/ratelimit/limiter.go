@@ -0,0 +1,130 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket rate limiter. It is safe for concurrent use.
+type Limiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter returns a limiter that allows rate requests per second with
+// bursts of at most burst requests. The rate must be positive.
+func NewLimiter(rate float64, burst int) (*Limiter, error) {
+	if !(rate > 0) || math.IsInf(rate, 1) {
+		return nil, fmt.Errorf("invalid rate %v: must be a positive number", rate)
+	}
+	burst = max(burst, 1)
+	return &Limiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}, nil
+}
+
+// Wait blocks until the limiter permits a request or ctx is done.
+func (l *Limiter) Wait(ctx context.Context) error {
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve takes a token and returns the time to wait until it is available.
+func (l *Limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns a token taken by a reservation that was not used.
+func (l *Limiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = min(l.burst, l.tokens+1)
+}
+
+// Throttler is a [Doer] that waits for limiters before sending requests.
+type Throttler struct {
+	client   Doer
+	basePath string
+	limiter  *Limiter
+	groups   map[string]*Limiter
+}
+
+// NewThrottler returns a throttler that waits for limiter before every
+// request, if it is not nil, and for group limiters before requests to paths
+// starting with group prefix. Prefixes are relative to basePath.
+func NewThrottler(client Doer, basePath string, limiter *Limiter, groups map[string]*Limiter) *Throttler {
+	return &Throttler{
+		client:   client,
+		basePath: basePath,
+		limiter:  limiter,
+		groups:   groups,
+	}
+}
+
+func (t *Throttler) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	group := t.group(req.URL.Path)
+	if group != nil {
+		if err := group.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if t.limiter != nil {
+		if err := t.limiter.Wait(ctx); err != nil {
+			// The request is not sent, so it must not use up the group
+			// budget either.
+			if group != nil {
+				group.cancel()
+			}
+			return nil, err
+		}
+	}
+	return t.client.Do(req)
+}
+
+// group returns a limiter of the longest prefix matching the path.
+func (t *Throttler) group(path string) *Limiter {
+	path = strings.TrimPrefix(path, t.basePath)
+	var (
+		limiter *Limiter
+		longest = -1
+	)
+	for prefix, l := range t.groups {
+		if len(prefix) > longest && strings.HasPrefix(path, prefix) {
+			limiter, longest = l, len(prefix)
+		}
+	}
+	return limiter
+}
@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func newLimiter(t *testing.T, rate float64, burst int) *Limiter {
+	t.Helper()
+	l, err := NewLimiter(rate, burst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func TestNewLimiterInvalidRate(t *testing.T) {
+	for _, rate := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		if _, err := NewLimiter(rate, 1); err == nil {
+			t.Errorf("NewLimiter(%v, 1) succeeded", rate)
+		}
+	}
+}
+
+func TestLimiterBurst(t *testing.T) {
+	l := newLimiter(t, 1, 3)
+	for i := range 3 {
+		if delay := l.reserve(); delay > 0 {
+			t.Fatalf("request %d within burst delayed by %v", i+1, delay)
+		}
+	}
+	if delay := l.reserve(); delay < 900*time.Millisecond || delay > time.Second {
+		t.Errorf("request beyond burst delayed by %v, want about a second", delay)
+	}
+}
+
+func TestLimiterWaitCancel(t *testing.T) {
+	l := newLimiter(t, 0.001, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Wait error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	// The cancelled reservation gives its token back.
+	if l.tokens < -0.01 || l.tokens > 0.01 {
+		t.Errorf("tokens after cancelled wait = %v, want 0", l.tokens)
+	}
+}
+
+func TestThrottlerGroup(t *testing.T) {
+	stash := newLimiter(t, 1, 1)
+	submit := newLimiter(t, 1, 1)
+	th := NewThrottler(nil, "/api/", nil, map[string]*Limiter{
+		"stash/":       stash,
+		"stash/submit": submit,
+	})
+	tests := []struct {
+		path string
+		want *Limiter
+	}{
+		{"/api/stash/submit", submit},
+		{"/api/stash/delta", stash},
+		{"/api/browse/newest", nil},
+	}
+	for _, tt := range tests {
+		if got := th.group(tt.path); got != tt.want {
+			t.Errorf("group(%q) = %p, want %p", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestThrottlerCancelReturnsGroupToken(t *testing.T) {
+	group := newLimiter(t, 1, 1)
+	global := newLimiter(t, 0.001, 1)
+	global.reserve() // Use up the global budget.
+
+	th := NewThrottler(doerFunc(func(*http.Request) (*http.Response, error) {
+		t.Fatal("throttled request is sent")
+		return nil, nil
+	}), "/", global, map[string]*Limiter{"stash/": group})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://example.com/stash/delta", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := th.Do(req); err != context.DeadlineExceeded {
+		t.Fatalf("Do error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if delay := group.reserve(); delay > 0 {
+		t.Errorf("group token is not returned, next request delayed by %v", delay)
+	}
+}
@@ -0,0 +1,228 @@
+package ratelimit
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// doerFunc is a stand-in transport.
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// replies returns a transport answering with the statuses in turn, repeating
+// the last one, and counts attempts.
+func replies(attempts *int, header http.Header, statuses ...int) Doer {
+	return doerFunc(func(req *http.Request) (*http.Response, error) {
+		status := statuses[min(*attempts, len(statuses)-1)]
+		*attempts++
+		return &http.Response{
+			StatusCode: status,
+			Header:     header.Clone(),
+			Body:       io.NopCloser(strings.NewReader("{}")),
+		}, nil
+	})
+}
+
+func newRequest(t *testing.T, method string) *http.Request {
+	t.Helper()
+	req, err := http.NewRequest(method, "https://example.com/api", strings.NewReader("body"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"seconds", "3", 3 * time.Second, true},
+		{"zero", "0", 0, true},
+		{"past date", "Mon, 02 Jan 2006 15:04:05 GMT", 0, true},
+		{"missing", "", 0, false},
+		{"negative", "-1", 0, false},
+		{"garbage", "soon", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.value != "" {
+				resp.Header.Set("Retry-After", tt.value)
+			}
+			got, ok := retryAfter(resp)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("retryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	t.Run("future date", func(t *testing.T) {
+		resp := &http.Response{Header: http.Header{}}
+		resp.Header.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+		got, ok := retryAfter(resp)
+		if !ok || got <= 58*time.Second || got > time.Minute {
+			t.Errorf("retryAfter = %v, %v, want about a minute", got, ok)
+		}
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	policy := Policy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	tests := []struct {
+		retry int
+		want  time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 5 * time.Second},
+		{100, 5 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := policy.backoff(tt.retry); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.retry, got, tt.want)
+		}
+	}
+
+	uncapped := Policy{BaseDelay: time.Second}
+	if got := uncapped.backoff(100); got <= 0 {
+		t.Errorf("uncapped backoff(100) = %v, want positive", got)
+	}
+}
+
+func TestHTTPClientDo(t *testing.T) {
+	fast := Policy{MaxAttempts: 3, BaseDelay: time.Millisecond, RetryServerErrors: true}
+	tests := []struct {
+		name     string
+		policy   Policy
+		method   string
+		header   http.Header
+		statuses []int
+
+		wantStatus   int
+		wantErr      error
+		wantAttempts int
+		wantStats    Stats
+	}{
+		{
+			name:         "success after throttling",
+			policy:       fast,
+			method:       http.MethodPost,
+			statuses:     []int{429, 200},
+			wantStatus:   200,
+			wantAttempts: 2,
+			wantStats:    Stats{Requests: 1, Attempts: 2, Retries: 1, Throttled: 1, Waited: time.Millisecond},
+		},
+		{
+			name:         "exhausted throttling",
+			policy:       fast,
+			method:       http.MethodGet,
+			statuses:     []int{429},
+			wantErr:      ErrMaxRetries,
+			wantAttempts: 3,
+			wantStats:    Stats{Requests: 1, Attempts: 3, Retries: 2, Throttled: 3, Exhausted: 1, Waited: 3 * time.Millisecond},
+		},
+		{
+			name:         "server error of GET",
+			policy:       fast,
+			method:       http.MethodGet,
+			statuses:     []int{503, 200},
+			wantStatus:   200,
+			wantAttempts: 2,
+			wantStats:    Stats{Requests: 1, Attempts: 2, Retries: 1, Waited: time.Millisecond},
+		},
+		{
+			name:         "server error of POST",
+			policy:       fast,
+			method:       http.MethodPost,
+			statuses:     []int{503, 200},
+			wantStatus:   503,
+			wantAttempts: 1,
+			wantStats:    Stats{Requests: 1, Attempts: 1},
+		},
+		{
+			name:         "server errors disabled",
+			policy:       Policy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+			method:       http.MethodGet,
+			statuses:     []int{500, 200},
+			wantStatus:   500,
+			wantAttempts: 1,
+			wantStats:    Stats{Requests: 1, Attempts: 1},
+		},
+		{
+			name:         "max wait",
+			policy:       Policy{MaxAttempts: 10, BaseDelay: 10 * time.Millisecond, MaxWait: 25 * time.Millisecond},
+			method:       http.MethodGet,
+			statuses:     []int{429},
+			wantErr:      ErrMaxRetries,
+			wantAttempts: 2,
+			wantStats:    Stats{Requests: 1, Attempts: 2, Retries: 1, Throttled: 2, Exhausted: 1, Waited: 10 * time.Millisecond},
+		},
+		{
+			name:         "retry after",
+			policy:       Policy{MaxAttempts: 2, BaseDelay: time.Hour, RespectRetryAfter: true},
+			method:       http.MethodGet,
+			header:       http.Header{"Retry-After": {"0"}},
+			statuses:     []int{429, 200},
+			wantStatus:   200,
+			wantAttempts: 2,
+			wantStats:    Stats{Requests: 1, Attempts: 2, Retries: 1, Throttled: 1},
+		},
+		{
+			name:         "retry after beyond max wait",
+			policy:       Policy{MaxAttempts: 5, BaseDelay: time.Millisecond, MaxWait: time.Second, RespectRetryAfter: true},
+			method:       http.MethodGet,
+			header:       http.Header{"Retry-After": {"60"}},
+			statuses:     []int{429, 200},
+			wantErr:      ErrMaxRetries,
+			wantAttempts: 1,
+			wantStats:    Stats{Requests: 1, Attempts: 1, Throttled: 1, Exhausted: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			c := NewHTTPClient(replies(&attempts, tt.header, tt.statuses...), tt.policy)
+			resp, err := c.Do(newRequest(t, tt.method))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Do error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+			if stats := c.Stats(); stats != tt.wantStats {
+				t.Errorf("stats = %+v, want %+v", stats, tt.wantStats)
+			}
+		})
+	}
+}
+
+func TestHTTPClientPermanentError(t *testing.T) {
+	errPermanent := errors.New("permanent")
+	attempts := 0
+	c := NewHTTPClient(doerFunc(func(*http.Request) (*http.Response, error) {
+		attempts++
+		return nil, errPermanent
+	}), Policy{MaxAttempts: 3, BaseDelay: time.Millisecond, RetryServerErrors: true})
+	c.Permanent = func(err error) bool { return errors.Is(err, errPermanent) }
+
+	if _, err := c.Do(newRequest(t, http.MethodGet)); !errors.Is(err, errPermanent) {
+		t.Errorf("Do error = %v, want %v", err, errPermanent)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
@@ -0,0 +1,229 @@
+package deviantart
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// ManagerConfig configures a [Manager].
+type ManagerConfig struct {
+	// Auth describes the application used to refresh user tokens. The
+	// interactive flow is never run by the manager, Auth.TokenStore is
+	// ignored in favor of TokenStore.
+	Auth AuthorizationCodeConfig
+
+	// TokenStore returns the token store of the user.
+	TokenStore func(userID string) TokenStore
+
+	// IdleTimeout evicts clients not requested for the duration. Zero value
+	// keeps clients until removed.
+	IdleTimeout time.Duration
+
+	// RateLimiter is shared by clients of all users to keep them under the
+	// application-wide budget. Nil value disables client-side throttling.
+	RateLimiter *RateLimiter
+
+	// Options are applied to the client of every user, e.g. [WithHTTPClient]
+	// to share a transport.
+	Options []Option
+}
+
+// Manager keeps clients of many users, keyed by user ID. Clients are created
+// lazily from stored tokens. It is safe for concurrent use.
+type Manager struct {
+	conf ManagerConfig
+
+	mu      sync.Mutex
+	clients map[string]*managedClient
+	reauth  map[string]error
+}
+
+type managedClient struct {
+	client   *Client
+	lastUsed time.Time
+}
+
+// NewManager returns a manager without any clients.
+func NewManager(conf ManagerConfig) *Manager {
+	return &Manager{
+		conf:    conf,
+		clients: make(map[string]*managedClient),
+		reauth:  make(map[string]error),
+	}
+}
+
+// Client returns the client of the user, creating it from the stored token
+// if needed. It fails with [AuthError] of [AuthInvalidGrant] kind if the user
+// has no token or the token is rejected; such users are reported by
+// [Manager.NeedsReauth] until they get a working token. Users are marked as
+// well once the token of their client cannot be refreshed or is rejected by
+// the API with invalid_token, and the client is dropped. Other failures, e.g.
+// network errors, are returned without marking the user.
+func (m *Manager) Client(userID string) (*Client, error) {
+	now := time.Now()
+	m.mu.Lock()
+	m.evictIdle(now)
+	if mc, ok := m.clients[userID]; ok {
+		mc.lastUsed = now
+		m.mu.Unlock()
+		return mc.client, nil
+	}
+	m.mu.Unlock()
+
+	mc := &managedClient{}
+	c, err := m.newClient(userID, mc)
+	if err != nil {
+		var authErr *AuthError
+		if errors.As(err, &authErr) && authErr.Kind == AuthInvalidGrant {
+			m.markReauth(userID, mc, err)
+		}
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.reauth, userID)
+	// Another goroutine may have created the client meanwhile.
+	if cur, ok := m.clients[userID]; ok {
+		cur.lastUsed = now
+		return cur.client, nil
+	}
+	mc.client, mc.lastUsed = c, now
+	m.clients[userID] = mc
+	return c, nil
+}
+
+// newClient creates the client of the user, which reports authentication
+// failures as failures of mc.
+func (m *Manager) newClient(userID string, mc *managedClient) (*Client, error) {
+	if m.conf.TokenStore == nil {
+		return nil, errors.New("unable to create client: token store is not configured")
+	}
+	store := m.conf.TokenStore(userID)
+	auth := func(ctx context.Context) (oauth2.TokenSource, error) {
+		ts, err := m.conf.Auth.storedTokenSource(ctx, m.conf.Auth.oauth2Config(), store)
+		if errors.Is(err, ErrNoToken) {
+			return nil, &AuthError{Kind: AuthInvalidGrant, Err: err}
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &managedTokenSource{TokenSource: ts, manager: m, userID: userID, client: mc}, nil
+	}
+
+	opts := slices.Clone(m.conf.Options)
+	if m.conf.RateLimiter != nil {
+		opts = append(opts, WithRateLimiter(m.conf.RateLimiter))
+	}
+	opts = append(opts, WithMiddleware(m.reauthMiddleware(userID, mc)))
+	c, err := NewClient(auth, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create client for user %s: %w", userID, err)
+	}
+	return c, nil
+}
+
+// Remove drops the client of the user and clears its re-authorization state,
+// e.g. after the user authorizes the application again.
+func (m *Manager) Remove(userID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.clients, userID)
+	delete(m.reauth, userID)
+}
+
+// EvictIdle drops clients not requested for longer than the idle timeout.
+// Idle clients are also evicted whenever a client is requested.
+func (m *Manager) EvictIdle() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.evictIdle(time.Now())
+}
+
+func (m *Manager) evictIdle(now time.Time) {
+	if m.conf.IdleTimeout <= 0 {
+		return
+	}
+	maps.DeleteFunc(m.clients, func(_ string, mc *managedClient) bool {
+		return now.Sub(mc.lastUsed) > m.conf.IdleTimeout
+	})
+}
+
+// NeedsReauth returns IDs of users who have to authorize the application
+// again, along with the reason.
+func (m *Manager) NeedsReauth() map[string]error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return maps.Clone(m.reauth)
+}
+
+// markReauth records that the user has to authorize the application again
+// and drops the broken client mc. Failures of a client replaced meanwhile,
+// e.g. after [Manager.Remove], do not affect its replacement.
+func (m *Manager) markReauth(userID string, mc *managedClient, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if cur, ok := m.clients[userID]; ok && cur != mc {
+		return
+	}
+	m.reauth[userID] = err
+	delete(m.clients, userID)
+}
+
+// reauthMiddleware reports users whose token is rejected by the API and
+// cannot be recovered, see [WithTokenRecovery].
+func (m *Manager) reauthMiddleware(userID string, mc *managedClient) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.Do(req)
+			var authErr *AuthError
+			switch {
+			case errors.As(err, &authErr) && authErr.Kind == AuthInvalidGrant:
+				m.markReauth(userID, mc, authErr)
+			case err == nil && invalidToken(resp):
+				m.markReauth(userID, mc, &AuthError{Kind: AuthInvalidGrant, Err: ErrInvalidToken})
+			}
+			return resp, err
+		})
+	}
+}
+
+// managedTokenSource reports users whose token cannot be refreshed anymore.
+type managedTokenSource struct {
+	oauth2.TokenSource
+	manager *Manager
+	userID  string
+	client  *managedClient
+}
+
+func (s *managedTokenSource) Token() (*oauth2.Token, error) {
+	tok, err := s.TokenSource.Token()
+	if err != nil {
+		err = newAuthError(err)
+		var authErr *AuthError
+		if errors.As(err, &authErr) && authErr.Kind == AuthInvalidGrant {
+			s.manager.markReauth(s.userID, s.client, err)
+		}
+		return nil, err
+	}
+	return tok, nil
+}
+
+func (s *managedTokenSource) unwrap() oauth2.TokenSource {
+	return s.TokenSource
+}
+
+func (s *managedTokenSource) Scopes() []string {
+	if sc, ok := s.TokenSource.(scoper); ok {
+		return sc.Scopes()
+	}
+	return nil
+}
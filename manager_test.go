@@ -0,0 +1,184 @@
+package deviantart
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// expiredTokenStore returns a store with a token that has to be refreshed.
+func expiredTokenStore() TokenStore {
+	store := NewMemoryTokenStore()
+	store.Save(&oauth2.Token{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		TokenType:    "Bearer",
+		Expiry:       time.Now().Add(-time.Hour),
+	})
+	return store
+}
+
+func newTestManager(tokenURL string, opts ...Option) *Manager {
+	return NewManager(ManagerConfig{
+		Auth: AuthorizationCodeConfig{
+			ClientID:     "client",
+			ClientSecret: "secret",
+			Endpoint:     oauth2.Endpoint{AuthURL: tokenURL, TokenURL: tokenURL},
+		},
+		TokenStore: func(string) TokenStore {
+			return expiredTokenStore()
+		},
+		Options: opts,
+	})
+}
+
+func TestManagerNetworkFailure(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	tokenURL := srv.URL + "/token"
+	// Refuse connections to the token endpoint.
+	srv.Close()
+
+	m := newTestManager(tokenURL)
+	_, err := m.Client("user")
+	var authErr *AuthError
+	if !errors.As(err, &authErr) || authErr.Kind != AuthNetwork {
+		t.Fatalf("Client error = %v, want network failure", err)
+	}
+	if reauth := m.NeedsReauth(); len(reauth) != 0 {
+		t.Errorf("NeedsReauth = %v, want none after network failure", reauth)
+	}
+}
+
+func TestManagerInvalidGrant(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, `{"error":"invalid_grant","error_description":"The refresh token is invalid."}`)
+	}))
+	defer srv.Close()
+
+	m := newTestManager(srv.URL + "/token")
+	_, err := m.Client("user")
+	var authErr *AuthError
+	if !errors.As(err, &authErr) || authErr.Kind != AuthInvalidGrant {
+		t.Fatalf("Client error = %v, want invalid grant", err)
+	}
+	if _, ok := m.NeedsReauth()["user"]; !ok {
+		t.Errorf("NeedsReauth = %v, want user marked", m.NeedsReauth())
+	}
+}
+
+func TestManagerValidationFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/token" {
+			io.WriteString(w, `{"access_token":"new","refresh_token":"refresh","token_type":"Bearer","expires_in":3600}`)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+		io.WriteString(w, `{"error":"server_error","error_description":"Service unavailable.","status":"error"}`)
+	}))
+	defer srv.Close()
+
+	m := newTestManager(srv.URL+"/token", WithBaseURL(srv.URL), WithTokenValidation(), WithRetryPolicy(RetryPolicy{}))
+	if _, err := m.Client("user"); err == nil {
+		t.Fatal("Client succeeded despite failed validation")
+	}
+	if reauth := m.NeedsReauth(); len(reauth) != 0 {
+		t.Errorf("NeedsReauth = %v, want none after server failure", reauth)
+	}
+}
+
+// rejectingServer is a stand-in for the token endpoint and the API, which
+// rejects access tokens once reject is set.
+func rejectingServer(t *testing.T, reject *atomic.Bool) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/token":
+			io.WriteString(w, `{"access_token":"new","refresh_token":"refresh","token_type":"Bearer","expires_in":3600}`)
+		case reject.Load():
+			w.WriteHeader(http.StatusUnauthorized)
+			io.WriteString(w, `{"error":"invalid_token","error_description":"Expired oAuth2 user token.","status":"error"}`)
+		default:
+			io.WriteString(w, `{"userid":"09a4052b-5b8b-4e69-9e2c-7c2e7e5b0b8f","username":"someone"}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestManagerInvalidTokenResponse(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts []Option
+	}{
+		{"without recovery", nil},
+		{"failed recovery", []Option{WithTokenRecovery(nil)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var reject atomic.Bool
+			srv := rejectingServer(t, &reject)
+			m := newTestManager(srv.URL+"/token", append(tc.opts, WithBaseURL(srv.URL))...)
+
+			c, err := m.Client("user")
+			if err != nil {
+				t.Fatalf("Client: %v", err)
+			}
+			reject.Store(true)
+			if _, err := c.User.Whoami(); !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("Whoami error = %v, want %v", err, ErrInvalidToken)
+			}
+			var authErr *AuthError
+			if err := m.NeedsReauth()["user"]; !errors.As(err, &authErr) || authErr.Kind != AuthInvalidGrant {
+				t.Errorf("NeedsReauth = %v, want user marked with invalid grant", m.NeedsReauth())
+			}
+
+			reject.Store(false)
+			next, err := m.Client("user")
+			if err != nil {
+				t.Fatalf("Client after reauthorization: %v", err)
+			}
+			if next == c {
+				t.Error("Client returned the broken client")
+			}
+			if reauth := m.NeedsReauth(); len(reauth) != 0 {
+				t.Errorf("NeedsReauth = %v, want none once the client works", reauth)
+			}
+		})
+	}
+}
+
+func TestManagerStaleClientFailure(t *testing.T) {
+	var reject atomic.Bool
+	srv := rejectingServer(t, &reject)
+	m := newTestManager(srv.URL+"/token", WithBaseURL(srv.URL))
+
+	stale, err := m.Client("user")
+	if err != nil {
+		t.Fatalf("Client: %v", err)
+	}
+	m.Remove("user")
+	fresh, err := m.Client("user")
+	if err != nil {
+		t.Fatalf("Client after Remove: %v", err)
+	}
+
+	reject.Store(true)
+	if _, err := stale.User.Whoami(); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("Whoami error = %v, want %v", err, ErrInvalidToken)
+	}
+	if reauth := m.NeedsReauth(); len(reauth) != 0 {
+		t.Errorf("NeedsReauth = %v, want none after failure of the removed client", reauth)
+	}
+	if c, err := m.Client("user"); err != nil || c != fresh {
+		t.Errorf("Client = %p, %v, want the client created after Remove %p", c, err, fresh)
+	}
+}
@@ -0,0 +1,190 @@
+package deviantart
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// MatureContentPolicy defines how mature deviations are handled.
+type MatureContentPolicy uint8
+
+const (
+	// MatureContentInclude requests mature deviations along with the rest.
+	MatureContentInclude MatureContentPolicy = iota
+
+	// MatureContentExclude asks DeviantArt to omit mature deviations.
+	MatureContentExclude
+
+	// MatureContentFilter asks DeviantArt to omit mature deviations and drops
+	// any mature deviation left in the response on the client side, including
+	// nested ones: they are removed from lists, such as deviations of a folder,
+	// and other fields holding them, such as a folder thumb, are left empty.
+	// Fetching a single mature deviation fails with [ErrMatureContent].
+	MatureContentFilter
+)
+
+// ErrMatureContent is returned when a response contains a mature deviation
+// that cannot be filtered out under [MatureContentFilter] policy.
+var ErrMatureContent = errors.New("mature content is filtered")
+
+// WithMatureContentPolicy sets the mature content policy for every request of
+// the client. Default policy is [MatureContentInclude].
+func WithMatureContentPolicy(policy MatureContentPolicy) Option {
+	return func(o *clientOptions) {
+		o.maturePolicy = policy
+	}
+}
+
+type maturePolicyKey struct{}
+
+// ContextWithMatureContentPolicy returns a copy of ctx that overrides the
+// client mature content policy for calls made with it.
+func ContextWithMatureContentPolicy(ctx context.Context, policy MatureContentPolicy) context.Context {
+	return context.WithValue(ctx, maturePolicyKey{}, policy)
+}
+
+// matureItem holds fields used to detect mature deviations and metadata.
+type matureItem struct {
+	IsMature    bool   `json:"is_mature"`
+	MatureLevel string `json:"mature_level"`
+}
+
+func (m matureItem) mature() bool {
+	return m.IsMature || m.MatureLevel != ""
+}
+
+// matureContentDoer applies mature content policy to the outgoing requests.
+type matureContentDoer struct {
+	next   Doer
+	policy MatureContentPolicy
+}
+
+func (d *matureContentDoer) Do(req *http.Request) (*http.Response, error) {
+	policy := d.policy
+	if p, ok := req.Context().Value(maturePolicyKey{}).(MatureContentPolicy); ok {
+		policy = p
+	}
+
+	req = req.Clone(req.Context())
+	query := req.URL.Query()
+	query.Set("mature_content", strconv.FormatBool(policy == MatureContentInclude))
+	req.URL.RawQuery = query.Encode()
+
+	resp, err := d.next.Do(req)
+	if err != nil || policy != MatureContentFilter {
+		return resp, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp, nil
+	}
+	if err := filterMatureContent(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+// filterMatureContent drops mature items from the response body at any
+// depth, see [filterMature].
+func filterMatureContent(resp *http.Response) error {
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	if isMature(data) {
+		return ErrMatureContent
+	}
+	if data, _, err = filterMature(data); err != nil {
+		return err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	resp.ContentLength = int64(len(data))
+	resp.Header.Del("Content-Length")
+	return nil
+}
+
+// filterMature removes mature items from arrays, such as results or
+// deviations of a folder, and replaces mature objects held by fields, such
+// as thumb, with null. Nested arrays and objects are filtered as well. It
+// reports whether the value is changed.
+func filterMature(value json.RawMessage) (json.RawMessage, bool, error) {
+	switch jsonKind(value) {
+	case '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(value, &items); err != nil {
+			return value, false, nil
+		}
+		changed := false
+		kept := items[:0]
+		for _, item := range items {
+			if isMature(item) {
+				changed = true
+				continue
+			}
+			item, itemChanged, err := filterMature(item)
+			if err != nil {
+				return nil, false, err
+			}
+			changed = changed || itemChanged
+			kept = append(kept, item)
+		}
+		if !changed {
+			return value, false, nil
+		}
+		data, err := json.Marshal(kept)
+		return data, err == nil, err
+
+	case '{':
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(value, &fields); err != nil {
+			return value, false, nil
+		}
+		changed := false
+		for key, field := range fields {
+			if isMature(field) {
+				fields[key] = json.RawMessage("null")
+				changed = true
+				continue
+			}
+			field, fieldChanged, err := filterMature(field)
+			if err != nil {
+				return nil, false, err
+			}
+			if fieldChanged {
+				fields[key] = field
+				changed = true
+			}
+		}
+		if !changed {
+			return value, false, nil
+		}
+		data, err := json.Marshal(fields)
+		return data, err == nil, err
+	}
+	return value, false, nil
+}
+
+// isMature reports whether the value is a mature deviation or metadata.
+func isMature(value json.RawMessage) bool {
+	if jsonKind(value) != '{' {
+		return false
+	}
+	var item matureItem
+	return json.Unmarshal(value, &item) == nil && item.mature()
+}
+
+// jsonKind returns the first significant byte of the value.
+func jsonKind(value json.RawMessage) byte {
+	value = bytes.TrimLeft(value, " \t\r\n")
+	if len(value) == 0 {
+		return 0
+	}
+	return value[0]
+}
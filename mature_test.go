@@ -0,0 +1,121 @@
+package deviantart
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func filterBody(t *testing.T, body string) (string, error) {
+	t.Helper()
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Length": {"1"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+	if err := filterMatureContent(resp); err != nil {
+		return "", err
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.ContentLength != int64(len(data)) || resp.Header.Get("Content-Length") != "" {
+		t.Errorf("content length = %d, header %q, want %d without header",
+			resp.ContentLength, resp.Header.Get("Content-Length"), len(data))
+	}
+	return string(data), nil
+}
+
+// equalJSON reports whether both documents hold the same value.
+func equalJSON(t *testing.T, got, want string) bool {
+	t.Helper()
+	var g, w any
+	if err := json.Unmarshal([]byte(got), &g); err != nil {
+		t.Fatalf("decode %q: %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Fatalf("decode %q: %v", want, err)
+	}
+	return reflect.DeepEqual(g, w)
+}
+
+func TestFilterMatureContent(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "results",
+			body: `{"has_more":false,"results":[{"deviationid":"a","is_mature":false},{"deviationid":"b","is_mature":true}]}`,
+			want: `{"has_more":false,"results":[{"deviationid":"a","is_mature":false}]}`,
+		},
+		{
+			name: "mature level",
+			body: `{"results":[{"deviationid":"a","mature_level":"strict"},{"deviationid":"b"}]}`,
+			want: `{"results":[{"deviationid":"b"}]}`,
+		},
+		{
+			name: "folder deviations and thumb",
+			body: `{"results":[{"folderid":"f","name":"Featured",` +
+				`"thumb":{"deviationid":"a","is_mature":true},` +
+				`"deviations":[{"deviationid":"a","is_mature":true},{"deviationid":"b","is_mature":false}]}]}`,
+			want: `{"results":[{"folderid":"f","name":"Featured","thumb":null,` +
+				`"deviations":[{"deviationid":"b","is_mature":false}]}]}`,
+		},
+		{
+			name: "nested arrays",
+			body: `{"entries":[[{"is_mature":true},{"id":1}]]}`,
+			want: `{"entries":[[{"id":1}]]}`,
+		},
+		{
+			name: "top-level array",
+			body: `[{"is_mature":true},{"id":1}]`,
+			want: `[{"id":1}]`,
+		},
+		{
+			name: "nothing mature",
+			body: `{"results":[{"deviationid":"a","stats":{"favourites":12345678901234567890}}]}`,
+			want: `{"results":[{"deviationid":"a","stats":{"favourites":12345678901234567890}}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := filterBody(t, tt.body)
+			if err != nil {
+				t.Fatalf("filterMatureContent: %v", err)
+			}
+			if !equalJSON(t, got, tt.want) {
+				t.Errorf("body = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterMatureContentUnchanged(t *testing.T) {
+	// Bodies without mature items are passed byte for byte.
+	for _, body := range []string{
+		`{"results":[{"deviationid":"a"}],"big":12345678901234567890}`,
+		`not json`,
+	} {
+		got, err := filterBody(t, body)
+		if err != nil {
+			t.Fatalf("filterMatureContent(%s): %v", body, err)
+		}
+		if got != body {
+			t.Errorf("body = %s, want it unchanged", got)
+		}
+	}
+}
+
+func TestFilterMatureContentSingleDeviation(t *testing.T) {
+	_, err := filterBody(t, `{"deviationid":"a","is_mature":true}`)
+	if !errors.Is(err, ErrMatureContent) {
+		t.Errorf("error = %v, want %v", err, ErrMatureContent)
+	}
+}
@@ -1,7 +1,9 @@
 package deviantart
 
 import (
+	"context"
 	"fmt"
+	"iter"
 
 	"github.com/dghubble/sling"
 	"github.com/google/uuid"
@@ -62,10 +64,15 @@ type DeleteMessageParams struct {
 //
 //   - message
 func (s *MessagesService) Delete(params *DeleteMessageParams) error {
+	return s.DeleteContext(context.Background(), params)
+}
+
+// DeleteContext is like [MessagesService.Delete] but uses ctx for the request.
+func (s *MessagesService) DeleteContext(ctx context.Context, params *DeleteMessageParams) error {
 	var (
 		failure Error
 	)
-	_, err := s.sling.New().Post("delete").BodyForm(params).Receive(nil, &failure)
+	_, err := receive(ctx, s.sling.New().Post("delete").BodyForm(params), nil, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return fmt.Errorf("unable to delete message: %w", err)
 	}
@@ -94,17 +101,29 @@ type MessagesFeedParams struct {
 //
 //   - message
 func (s *MessagesService) Feed(params *MessagesFeedParams, page *CursorParams) (CursorResponse[Message], error) {
+	return s.FeedContext(context.Background(), params, page)
+}
+
+// FeedContext is like [MessagesService.Feed] but uses ctx for the request.
+func (s *MessagesService) FeedContext(ctx context.Context, params *MessagesFeedParams, page *CursorParams) (CursorResponse[Message], error) {
 	var (
 		success CursorResponse[Message]
 		failure Error
 	)
-	_, err := s.sling.New().Get("feed").QueryStruct(params).QueryStruct(page).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("feed").QueryStruct(params).QueryStruct(page), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return CursorResponse[Message]{}, fmt.Errorf("unable to fetch message feed: %w", err)
 	}
 	return success, nil
 }
 
+// FeedIter iterates over all pages of [MessagesService.Feed].
+func (s *MessagesService) FeedIter(ctx context.Context, params *MessagesFeedParams, opts *IterOptions) iter.Seq2[Message, error] {
+	return iterCursor(ctx, opts, iterOrigin("Messages.Feed", params), func(ctx context.Context, page *CursorParams) (CursorResponse[Message], error) {
+		return s.FeedContext(ctx, params, page)
+	})
+}
+
 type MessagesFeedbackParams struct {
 	// Type of feedback messages to fetch.
 	Type string `url:"type"`
@@ -129,18 +148,30 @@ type MessagesFeedbackParams struct {
 // The following scopes are required to access this resource:
 //
 //   - message
-func (s *MessagesService) Feedback(params *MessagesFeedbackParams, page *OffsetParams) (CursorResponse[Message], error) {
+func (s *MessagesService) Feedback(params *MessagesFeedbackParams, page *OffsetParams) (OffsetResponse[Message], error) {
+	return s.FeedbackContext(context.Background(), params, page)
+}
+
+// FeedbackContext is like [MessagesService.Feedback] but uses ctx for the request.
+func (s *MessagesService) FeedbackContext(ctx context.Context, params *MessagesFeedbackParams, page *OffsetParams) (OffsetResponse[Message], error) {
 	var (
-		success CursorResponse[Message]
+		success OffsetResponse[Message]
 		failure Error
 	)
-	_, err := s.sling.New().Get("feedback").QueryStruct(params).QueryStruct(page).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("feedback").QueryStruct(params).QueryStruct(page), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
-		return CursorResponse[Message]{}, fmt.Errorf("unable to fetch message feedback: %w", err)
+		return OffsetResponse[Message]{}, fmt.Errorf("unable to fetch message feedback: %w", err)
 	}
 	return success, nil
 }
 
+// FeedbackIter iterates over all pages of [MessagesService.Feedback].
+func (s *MessagesService) FeedbackIter(ctx context.Context, params *MessagesFeedbackParams, opts *IterOptions) iter.Seq2[Message, error] {
+	return iterOffset(ctx, opts, iterOrigin("Messages.Feedback", params), func(ctx context.Context, page *OffsetParams) (OffsetResponse[Message], error) {
+		return s.FeedbackContext(ctx, params, page)
+	})
+}
+
 // Fetch messages in a stack.
 //
 // To connect to this endpoint OAuth2 Access Token from the Authorization Code
@@ -149,18 +180,30 @@ func (s *MessagesService) Feedback(params *MessagesFeedbackParams, page *OffsetP
 // The following scopes are required to access this resource:
 //
 //   - message
-func (s *MessagesService) StackFeedback(stackID string, page *OffsetParams) (CursorResponse[Message], error) {
+func (s *MessagesService) StackFeedback(stackID string, page *OffsetParams) (OffsetResponse[Message], error) {
+	return s.StackFeedbackContext(context.Background(), stackID, page)
+}
+
+// StackFeedbackContext is like [MessagesService.StackFeedback] but uses ctx for the request.
+func (s *MessagesService) StackFeedbackContext(ctx context.Context, stackID string, page *OffsetParams) (OffsetResponse[Message], error) {
 	var (
-		success CursorResponse[Message]
+		success OffsetResponse[Message]
 		failure Error
 	)
-	_, err := s.sling.New().Get("feedback").Path(stackID).QueryStruct(page).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("feedback").Path(stackID).QueryStruct(page), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
-		return CursorResponse[Message]{}, fmt.Errorf("unable to fetch stack feedback: %w", err)
+		return OffsetResponse[Message]{}, fmt.Errorf("unable to fetch stack feedback: %w", err)
 	}
 	return success, nil
 }
 
+// StackFeedbackIter iterates over all pages of [MessagesService.StackFeedback].
+func (s *MessagesService) StackFeedbackIter(ctx context.Context, stackID string, opts *IterOptions) iter.Seq2[Message, error] {
+	return iterOffset(ctx, opts, iterOrigin("Messages.StackFeedback", stackID), func(ctx context.Context, page *OffsetParams) (OffsetResponse[Message], error) {
+		return s.StackFeedbackContext(ctx, stackID, page)
+	})
+}
+
 type MessagesMentionsParams struct {
 	// The folder to fetch messages from, defaults to inbox.
 	FolderID uuid.UUID `url:"folderid,omitempty"`
@@ -189,17 +232,34 @@ type MessagesMentionsParams struct {
 //
 //   - message
 func (s *MessagesService) Mentions(params *MessagesMentionsParams) (OffsetResponse[Message], error) {
+	return s.MentionsContext(context.Background(), params)
+}
+
+// MentionsContext is like [MessagesService.Mentions] but uses ctx for the request.
+func (s *MessagesService) MentionsContext(ctx context.Context, params *MessagesMentionsParams) (OffsetResponse[Message], error) {
 	var (
 		success OffsetResponse[Message]
 		failure Error
 	)
-	_, err := s.sling.New().Get("mentions").QueryStruct(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("mentions").QueryStruct(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return OffsetResponse[Message]{}, fmt.Errorf("unable to fetch message mentions: %w", err)
 	}
 	return success, nil
 }
 
+// MentionsIter iterates over all pages of [MessagesService.Mentions].
+func (s *MessagesService) MentionsIter(ctx context.Context, params *MessagesMentionsParams, opts *IterOptions) iter.Seq2[Message, error] {
+	return iterOffset(ctx, opts, iterOrigin("Messages.Mentions", params), func(ctx context.Context, page *OffsetParams) (OffsetResponse[Message], error) {
+		p := MessagesMentionsParams{}
+		if params != nil {
+			p = *params
+		}
+		p.Offset, p.Limit = int(page.Offset), int(page.Limit)
+		return s.MentionsContext(ctx, &p)
+	})
+}
+
 // StackMentions fetches messages in a stack.
 //
 // To connect to this endpoint OAuth2 Access Token from the Authorization Code
@@ -209,13 +269,25 @@ func (s *MessagesService) Mentions(params *MessagesMentionsParams) (OffsetRespon
 //
 //   - message
 func (s *MessagesService) StackMentions(stackID string, page *OffsetParams) (OffsetResponse[Message], error) {
+	return s.StackMentionsContext(context.Background(), stackID, page)
+}
+
+// StackMentionsContext is like [MessagesService.StackMentions] but uses ctx for the request.
+func (s *MessagesService) StackMentionsContext(ctx context.Context, stackID string, page *OffsetParams) (OffsetResponse[Message], error) {
 	var (
 		success OffsetResponse[Message]
 		failure Error
 	)
-	_, err := s.sling.New().Get("mentions/").Path(stackID).QueryStruct(page).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("mentions/").Path(stackID).QueryStruct(page), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return OffsetResponse[Message]{}, fmt.Errorf("unable to fetch stack mentions: %w", err)
 	}
 	return success, nil
 }
+
+// StackMentionsIter iterates over all pages of [MessagesService.StackMentions].
+func (s *MessagesService) StackMentionsIter(ctx context.Context, stackID string, opts *IterOptions) iter.Seq2[Message, error] {
+	return iterOffset(ctx, opts, iterOrigin("Messages.StackMentions", stackID), func(ctx context.Context, page *OffsetParams) (OffsetResponse[Message], error) {
+		return s.StackMentionsContext(ctx, stackID, page)
+	})
+}
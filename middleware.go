@@ -0,0 +1,61 @@
+package deviantart
+
+import "net/http"
+
+// Doer sends HTTP requests. [*http.Client] implements it.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is an adapter to use ordinary functions as [Doer].
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps a [Doer] to add behavior to outgoing requests, such as
+// logging, metrics, caching or fault injection.
+type Middleware func(next Doer) Doer
+
+// WithMiddleware adds middleware run once per API call. The first middleware
+// is the outermost one. Layers of the client, from the outermost, are:
+//
+//  1. scope check, see [WithScopeCheck];
+//  2. mature content policy, which sets the mature_content query parameter;
+//  3. response capture, see [ContextWithResponse];
+//  4. middleware added by WithMiddleware;
+//  5. invalid token recovery, see [WithTokenRecovery];
+//  6. retries, see [WithRetryPolicy];
+//  7. throttling, see [WithRateLimiter];
+//  8. middleware added by [WithAttemptMiddleware];
+//  9. authorization, which sets the Authorization header;
+//  10. the HTTP client, see [WithHTTPClient].
+//
+// So middleware added by WithMiddleware sees the final outcome of the call
+// after retries and token recovery, but neither sees nor may alter the
+// Authorization header.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(o *clientOptions) {
+		o.middleware = append(o.middleware, middleware...)
+	}
+}
+
+// WithAttemptMiddleware adds middleware run for every attempt of an API call,
+// including retries, once the request passed throttling. The first
+// middleware is the outermost one. See [WithMiddleware] for the order of
+// layers.
+func WithAttemptMiddleware(middleware ...Middleware) Option {
+	return func(o *clientOptions) {
+		o.attemptMiddleware = append(o.attemptMiddleware, middleware...)
+	}
+}
+
+// chain wraps the doer with middleware, the first one being the outermost.
+func chain(doer Doer, middleware []Middleware) Doer {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		doer = middleware[i](doer)
+	}
+	return doer
+}
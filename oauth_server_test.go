@@ -0,0 +1,150 @@
+package deviantart
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+// oauthServer is a stand-in for the DeviantArt token and revoke endpoints and
+// the API. It records forms sent to the token and revoke endpoints, and tokens
+// and bodies sent to the API.
+type oauthServer struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	invalidRevoke bool
+	tokenError    string
+	accepted      string
+	tokenForms    []url.Values
+	revokes       []url.Values
+	tokens        []string
+	bodies        []string
+}
+
+func newOAuthServer(t *testing.T) *oauthServer {
+	t.Helper()
+	s := &oauthServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/token":
+			r.ParseForm()
+			s.mu.Lock()
+			s.tokenForms = append(s.tokenForms, r.PostForm)
+			tokenError := s.tokenError
+			s.mu.Unlock()
+			if tokenError != "" {
+				w.WriteHeader(http.StatusBadRequest)
+				io.WriteString(w, `{"error":"`+tokenError+`","error_description":"Token request is rejected."}`)
+				return
+			}
+			io.WriteString(w, `{"access_token":"access","refresh_token":"refresh","token_type":"Bearer","expires_in":3600}`)
+		case "/revoke":
+			r.ParseForm()
+			s.mu.Lock()
+			s.revokes = append(s.revokes, r.PostForm)
+			invalid := s.invalidRevoke
+			s.mu.Unlock()
+			if invalid {
+				w.WriteHeader(http.StatusUnauthorized)
+				io.WriteString(w, `{"error":"invalid_token","error_description":"Expired oAuth2 user token.","status":"error"}`)
+				return
+			}
+			io.WriteString(w, `{"status":"success"}`)
+		default:
+			body, _ := io.ReadAll(r.Body)
+			s.mu.Lock()
+			s.tokens = append(s.tokens, r.Header.Get("Authorization"))
+			s.bodies = append(s.bodies, string(body))
+			accepted := s.accepted
+			s.mu.Unlock()
+			if accepted != "" && r.Header.Get("Authorization") != "Bearer "+accepted {
+				w.WriteHeader(http.StatusUnauthorized)
+				io.WriteString(w, `{"error":"invalid_token","error_description":"Expired oAuth2 user token.","status":"error"}`)
+				return
+			}
+			io.WriteString(w, `{"userid":"09a4052b-5b8b-4e69-9e2c-7c2e7e5b0b8f","username":"someone"}`)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// rejectRevoke makes the revoke endpoint reject tokens as invalid.
+func (s *oauthServer) rejectRevoke() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.invalidRevoke = true
+}
+
+// rejectTokenRequests makes the token endpoint fail with the OAuth2 error
+// code.
+func (s *oauthServer) rejectTokenRequests(code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokenError = code
+}
+
+// acceptOnly makes the API reject access tokens other than the given one as
+// invalid.
+func (s *oauthServer) acceptOnly(accessToken string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accepted = accessToken
+}
+
+// config returns the Authorization Code grant configuration using the server
+// endpoints.
+func (s *oauthServer) config(pkce bool) *AuthorizationCodeConfig {
+	return &AuthorizationCodeConfig{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost/callback",
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  s.URL + "/authorize",
+			TokenURL: s.URL + "/token",
+		},
+		PKCE: pkce,
+	}
+}
+
+// client returns a client of the server API authenticated with auth.
+func (s *oauthServer) client(t *testing.T, auth Authenticator, opts ...Option) *Client {
+	t.Helper()
+	opts = append([]Option{WithBaseURL(s.URL), WithRevokeURL(s.URL + "/revoke")}, opts...)
+	c, err := NewClient(auth, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func (s *oauthServer) tokenRequests() []url.Values {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tokenForms
+}
+
+func (s *oauthServer) revokeForms() []url.Values {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.revokes
+}
+
+func (s *oauthServer) apiTokens() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tokens
+}
+
+func (s *oauthServer) apiBodies() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bodies
+}
@@ -1,5 +1,13 @@
 package deviantart
 
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+)
+
 // OffsetParams params for offset-based pagination.
 type OffsetParams struct {
 	// The pagination offset.
@@ -14,8 +22,11 @@ type OffsetResponse[T any] struct {
 	HasMore    bool   `json:"has_more"`
 	NextOffset uint32 `json:"next_offset,omitempty"`
 
-	// This field is used in some endpoints with a query parameter.
-	// TODO: Use separate struct and API method (?).
+	// EstimatedTotal is set by some endpoints when the query parameter is
+	// given.
+	//
+	// Deprecated: use [BrowseService.Search] and [SearchResult.EstimatedTotal]
+	// instead.
 	EstimatedTotal uint32 `json:"estimated_total,omitempty"`
 }
 
@@ -29,6 +40,9 @@ func (o *OffsetResponse[T]) Next() *OffsetParams {
 // CursorParams params for cursor-based pagination.
 type CursorParams struct {
 	Cursor string `url:"cursor,omitempty"`
+
+	// The pagination limit. Not all cursor-based endpoints support it.
+	Limit uint32 `url:"limit,omitempty"`
 }
 
 type CursorResponse[T any] struct {
@@ -48,3 +62,284 @@ func (c *CursorResponse[T]) Next() *CursorParams {
 type singleResponse[T any] struct {
 	Results []T `json:"results"`
 }
+
+// IterOptions configures iteration over paginated endpoints.
+type IterOptions struct {
+	// The number of items requested per page. Zero value uses the endpoint
+	// default.
+	PageSize uint32
+
+	// The maximum number of items to iterate over. Zero value means no limit.
+	MaxItems int
+
+	// The number of pages fetched ahead in parallel by offset-based
+	// endpoints. Pages are yielded in order. Items that shift between pages
+	// while iterating are yielded once only if they have an identifier, which
+	// is currently the case for [Deviation] only; other items may be yielded
+	// twice. Requests made ahead are subject to the client rate limiting as
+	// any other request. Zero value disables prefetching. Cursor-based
+	// endpoints ignore this option.
+	Prefetch int
+
+	// Checkpoint tracks the position of the iteration. An empty checkpoint is
+	// filled in when the iteration starts, a non-empty one resumes the
+	// iteration right after the last item yielded before. The checkpoint may
+	// be persisted at any time, including inside the loop body.
+	Checkpoint *Checkpoint
+}
+
+// ErrCheckpointMismatch is returned when a checkpoint is used to resume an
+// iteration over different endpoint or parameters.
+var ErrCheckpointMismatch = errors.New("checkpoint does not match the iteration")
+
+// Checkpoint is a serializable position of an iteration over a paginated
+// endpoint.
+type Checkpoint struct {
+	// The endpoint the iteration originates from.
+	Endpoint string `json:"endpoint"`
+
+	// The encoded parameters the iteration originates from.
+	Params string `json:"params,omitempty"`
+
+	// The offset of the current page for offset-based endpoints.
+	Offset uint32 `json:"offset,omitempty"`
+
+	// The cursor of the current page for cursor-based endpoints.
+	Cursor string `json:"cursor,omitempty"`
+
+	// The number of items of the current page already yielded.
+	Skip int `json:"skip,omitempty"`
+
+	// Whether the iteration has reached the last page.
+	Done bool `json:"done,omitempty"`
+}
+
+// iterOrigin returns an initial checkpoint for the endpoint called with args.
+func iterOrigin(endpoint string, args ...any) Checkpoint {
+	params, err := json.Marshal(args)
+	if err != nil {
+		params = []byte(fmt.Sprint(args...))
+	}
+	return Checkpoint{Endpoint: endpoint, Params: string(params)}
+}
+
+// page is a single page of paginated endpoint.
+type page[T any] struct {
+	results    []T
+	hasMore    bool
+	nextOffset uint32
+	nextCursor string
+}
+
+// iterOffset iterates over items of offset-based endpoint following
+// `has_more` and `next_offset` fields. The iteration stops after the first
+// error.
+func iterOffset[T any](
+	ctx context.Context,
+	opts *IterOptions,
+	origin Checkpoint,
+	fetch func(ctx context.Context, page *OffsetParams) (OffsetResponse[T], error),
+) iter.Seq2[T, error] {
+	if opts == nil {
+		opts = &IterOptions{}
+	}
+	toPage := func(offset uint32, resp OffsetResponse[T]) page[T] {
+		return page[T]{
+			results: resp.Results,
+			// Guard against endpoints that report more items without moving
+			// the offset forward.
+			hasMore:    resp.HasMore && resp.NextOffset > offset,
+			nextOffset: resp.NextOffset,
+		}
+	}
+	if opts.Prefetch <= 0 {
+		return iterPages(ctx, opts, origin, func(ctx context.Context, cp *Checkpoint, limit uint32) (page[T], error) {
+			resp, err := fetch(ctx, &OffsetParams{Offset: cp.Offset, Limit: limit})
+			return toPage(cp.Offset, resp), err
+		})
+	}
+	return func(yield func(T, error) bool) {
+		// Stop pages fetched ahead once the iteration is over.
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		pf := &prefetcher[T]{
+			fetch:   fetch,
+			ahead:   opts.Prefetch,
+			pending: make(map[uint32]chan prefetchResult[T]),
+		}
+		pages := iterPages(ctx, opts, origin, func(ctx context.Context, cp *Checkpoint, limit uint32) (page[T], error) {
+			resp, err := pf.get(ctx, cp.Offset, limit)
+			return toPage(cp.Offset, resp), err
+		})
+		for item, err := range pages {
+			if !yield(item, err) {
+				return
+			}
+		}
+	}
+}
+
+type prefetchResult[T any] struct {
+	resp OffsetResponse[T]
+	err  error
+}
+
+// prefetcher fetches pages of offset-based endpoint ahead of time assuming
+// that page size does not change between pages.
+type prefetcher[T any] struct {
+	fetch   func(ctx context.Context, page *OffsetParams) (OffsetResponse[T], error)
+	ahead   int
+	pending map[uint32]chan prefetchResult[T]
+}
+
+// get returns the page at offset and starts fetching the following pages.
+func (p *prefetcher[T]) get(ctx context.Context, offset, limit uint32) (OffsetResponse[T], error) {
+	result, ok := p.pending[offset]
+	if !ok {
+		result = p.start(ctx, offset, limit)
+	}
+	delete(p.pending, offset)
+
+	var res prefetchResult[T]
+	select {
+	case <-ctx.Done():
+		return OffsetResponse[T]{}, ctx.Err()
+	case res = <-result:
+	}
+	if res.err != nil || !res.resp.HasMore || res.resp.NextOffset <= offset {
+		return res.resp, res.err
+	}
+
+	// Drop predictions that do not match the actual page layout.
+	for o := range p.pending {
+		if o < res.resp.NextOffset || (o-res.resp.NextOffset)%(res.resp.NextOffset-offset) != 0 {
+			delete(p.pending, o)
+		}
+	}
+	stride := res.resp.NextOffset - offset
+	for i := range uint32(p.ahead) {
+		next := res.resp.NextOffset + i*stride
+		if _, ok := p.pending[next]; !ok {
+			p.pending[next] = p.start(ctx, next, limit)
+		}
+	}
+	return res.resp, nil
+}
+
+func (p *prefetcher[T]) start(ctx context.Context, offset, limit uint32) chan prefetchResult[T] {
+	result := make(chan prefetchResult[T], 1)
+	go func() {
+		resp, err := p.fetch(ctx, &OffsetParams{Offset: offset, Limit: limit})
+		result <- prefetchResult[T]{resp: resp, err: err}
+	}()
+	return result
+}
+
+// iterCursor iterates over items of cursor-based endpoint following
+// `has_more` and `next_cursor` fields. The iteration stops after the first
+// error.
+func iterCursor[T any](
+	ctx context.Context,
+	opts *IterOptions,
+	origin Checkpoint,
+	fetch func(ctx context.Context, page *CursorParams) (CursorResponse[T], error),
+) iter.Seq2[T, error] {
+	return iterPages(ctx, opts, origin, func(ctx context.Context, cp *Checkpoint, limit uint32) (page[T], error) {
+		resp, err := fetch(ctx, &CursorParams{Cursor: cp.Cursor, Limit: limit})
+		return page[T]{
+			results:    resp.Results,
+			hasMore:    resp.HasMore && resp.NextCursor != "" && resp.NextCursor != cp.Cursor,
+			nextCursor: resp.NextCursor,
+		}, err
+	})
+}
+
+// iterPages iterates over items of paginated endpoint and keeps the checkpoint
+// up to date.
+func iterPages[T any](
+	ctx context.Context,
+	opts *IterOptions,
+	origin Checkpoint,
+	fetch func(ctx context.Context, cp *Checkpoint, limit uint32) (page[T], error),
+) iter.Seq2[T, error] {
+	if opts == nil {
+		opts = &IterOptions{}
+	}
+	return func(yield func(T, error) bool) {
+		var zero T
+		cp := opts.Checkpoint
+		if cp == nil {
+			cp = &Checkpoint{}
+		}
+		switch {
+		case cp.Endpoint == "":
+			*cp = origin
+		case cp.Endpoint != origin.Endpoint || cp.Params != origin.Params:
+			yield(zero, ErrCheckpointMismatch)
+			return
+		}
+
+		var seen itemSet
+		if opts.Prefetch > 0 {
+			seen = make(itemSet)
+		}
+		count := 0
+		for !cp.Done {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			p, err := fetch(ctx, cp, opts.PageSize)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range p.results[min(cp.Skip, len(p.results)):] {
+				if opts.MaxItems > 0 && count >= opts.MaxItems {
+					return
+				}
+				// The item is considered consumed once it is yielded.
+				cp.Skip++
+				if seen != nil && seen.dup(item) {
+					continue
+				}
+				if !yield(item, nil) {
+					return
+				}
+				count++
+			}
+			if !p.hasMore {
+				cp.Done = true
+				return
+			}
+			cp.Offset, cp.Cursor, cp.Skip = p.nextOffset, p.nextCursor, 0
+			if opts.MaxItems > 0 && count >= opts.MaxItems {
+				return
+			}
+		}
+	}
+}
+
+// identifiable is implemented by items that have a unique identifier.
+type identifiable interface {
+	itemID() any
+}
+
+// itemSet tracks identifiers of items already seen.
+type itemSet map[any]struct{}
+
+// dup reports whether the item has been seen before. Items without an
+// identifier are never considered duplicates.
+func (s itemSet) dup(item any) bool {
+	i, ok := item.(identifiable)
+	if !ok {
+		return false
+	}
+	id := i.itemID()
+	if _, ok := s[id]; ok {
+		return true
+	}
+	s[id] = struct{}{}
+	return false
+}
@@ -0,0 +1,229 @@
+package deviantart
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// pageServer is a stand-in for an offset-based endpoint listing deviations.
+type pageServer struct {
+	*httptest.Server
+
+	mu    sync.Mutex
+	items []uuid.UUID
+
+	// hook is called with the offset of every request once the page is
+	// picked and before it is sent.
+	hook func(r *http.Request, offset int)
+}
+
+func newPageServer(t *testing.T, n int) *pageServer {
+	t.Helper()
+	s := &pageServer{}
+	for i := range n {
+		s.items = append(s.items, deviationID(i))
+	}
+	s.Server = httptest.NewServer(s)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func deviationID(i int) uuid.UUID {
+	return uuid.MustParse(fmt.Sprintf("00000000-0000-0000-0000-%012d", i))
+}
+
+func (s *pageServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	s.mu.Lock()
+	end := min(offset+limit, len(s.items))
+	resp := OffsetResponse[Deviation]{HasMore: end < len(s.items)}
+	if resp.HasMore {
+		resp.NextOffset = uint32(end)
+	}
+	for _, id := range s.items[min(offset, end):end] {
+		resp.Results = append(resp.Results, Deviation{DeviationID: id})
+	}
+	s.mu.Unlock()
+
+	if s.hook != nil {
+		s.hook(r, offset)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *pageServer) client(t *testing.T) *Client {
+	t.Helper()
+	c, err := NewClient(StaticToken("token"), WithBaseURL(s.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func collectIDs(t *testing.T, items func(func(Deviation, error) bool)) []uuid.UUID {
+	t.Helper()
+	var ids []uuid.UUID
+	for item, err := range items {
+		if err != nil {
+			t.Fatalf("iteration: %v", err)
+		}
+		ids = append(ids, item.DeviationID)
+	}
+	return ids
+}
+
+func TestPrefetchOrder(t *testing.T) {
+	srv := newPageServer(t, 10)
+	c := srv.client(t)
+
+	// The page at offset 2 is held until the page at offset 4 is received.
+	received := make(chan struct{})
+	srv.hook = func(r *http.Request, offset int) {
+		if offset != 2 {
+			return
+		}
+		select {
+		case <-received:
+		case <-time.After(5 * time.Second):
+		}
+	}
+
+	var (
+		mu    sync.Mutex
+		order []uint32
+	)
+	fetch := func(ctx context.Context, page *OffsetParams) (OffsetResponse[Deviation], error) {
+		resp, err := c.Browse.NewestContext(ctx, "", page)
+		mu.Lock()
+		order = append(order, page.Offset)
+		mu.Unlock()
+		if page.Offset == 4 {
+			close(received)
+		}
+		return resp, err
+	}
+	opts := &IterOptions{PageSize: 2, Prefetch: 3}
+	ids := collectIDs(t, iterOffset(context.Background(), opts, iterOrigin("Browse.Newest", ""), fetch))
+
+	if !slices.Equal(ids, srv.items) {
+		t.Errorf("items = %v, want %v", ids, srv.items)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if slices.Index(order, 4) > slices.Index(order, 2) {
+		t.Errorf("pages completed in order %v, want offset 4 before offset 2", order)
+	}
+}
+
+func TestPrefetchDeduplicates(t *testing.T) {
+	srv := newPageServer(t, 6)
+	c := srv.client(t)
+
+	// A new deviation is published once the first page is sent, so the last
+	// deviation of the first page shifts to the second one.
+	want := slices.Clone(srv.items)
+	var once sync.Once
+	srv.hook = func(r *http.Request, offset int) {
+		if offset != 0 {
+			return
+		}
+		once.Do(func() {
+			srv.mu.Lock()
+			srv.items = slices.Insert(srv.items, 0, deviationID(100))
+			srv.mu.Unlock()
+		})
+	}
+
+	ids := collectIDs(t, c.Browse.NewestIter(context.Background(), "", &IterOptions{PageSize: 2, Prefetch: 2}))
+	if !slices.Equal(ids, want) {
+		t.Errorf("items = %v, want %v", ids, want)
+	}
+}
+
+func TestPrefetchMaxItems(t *testing.T) {
+	srv := newPageServer(t, 20)
+	c := srv.client(t)
+
+	cp := &Checkpoint{}
+	opts := &IterOptions{PageSize: 3, MaxItems: 5, Prefetch: 2, Checkpoint: cp}
+	ids := collectIDs(t, c.Browse.NewestIter(context.Background(), "", opts))
+	if want := srv.items[:5]; !slices.Equal(ids, want) {
+		t.Errorf("items = %v, want %v", ids, want)
+	}
+
+	// The checkpoint resumes right after the last item yielded.
+	opts.MaxItems = 0
+	ids = collectIDs(t, c.Browse.NewestIter(context.Background(), "", opts))
+	if want := srv.items[5:]; !slices.Equal(ids, want) {
+		t.Errorf("resumed items = %v, want %v", ids, want)
+	}
+}
+
+func TestPrefetchCancel(t *testing.T) {
+	srv := newPageServer(t, 100)
+	c := srv.client(t)
+
+	// Pages fetched ahead are only sent once the request is cancelled.
+	var (
+		mu     sync.Mutex
+		active int
+	)
+	srv.hook = func(r *http.Request, offset int) {
+		if offset == 0 {
+			return
+		}
+		mu.Lock()
+		active++
+		mu.Unlock()
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+		mu.Lock()
+		active--
+		mu.Unlock()
+	}
+
+	for _, err := range c.Browse.NewestIter(context.Background(), "", &IterOptions{PageSize: 2, Prefetch: 4}) {
+		if err != nil {
+			t.Fatalf("iteration: %v", err)
+		}
+		break
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		mu.Lock()
+		n := active
+		mu.Unlock()
+		if n == 0 && !prefetching() {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d requests still active after the iteration stopped, prefetching: %t", n, prefetching())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// prefetching reports whether any goroutine started by a prefetcher is still
+// running.
+func prefetching() bool {
+	buf := make([]byte, 1<<20)
+	buf = buf[:runtime.Stack(buf, true)]
+	return strings.Contains(string(buf), "(*prefetcher[...]).start")
+}
@@ -0,0 +1,207 @@
+package deviantart
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// WithTokenRecovery makes the client recover from invalid_token errors, e.g.
+// when the token is revoked or expires on DeviantArt side. The client forces
+// a token refresh, or calls reauth if the token cannot be refreshed, and
+// replays the failed request once. If the recovery fails, the request fails
+// with [AuthError]. The reauth may be nil.
+func WithTokenRecovery(reauth Authenticator) Option {
+	return func(o *clientOptions) {
+		o.recovery = true
+		o.reauth = reauth
+	}
+}
+
+// refreshTokenSource reuses a token until it expires, like
+// [oauth2.ReuseTokenSource], and allows to force a refresh.
+type refreshTokenSource struct {
+	renew func(tok *oauth2.Token) oauth2.TokenSource
+
+	mu  sync.Mutex
+	ts  oauth2.TokenSource
+	tok *oauth2.Token
+}
+
+// newRefreshTokenSource returns a token source starting from tok, which may be
+// nil. The renew func returns a source that obtains a new token, given the
+// last known one.
+func newRefreshTokenSource(tok *oauth2.Token, renew func(tok *oauth2.Token) oauth2.TokenSource) *refreshTokenSource {
+	return &refreshTokenSource{renew: renew, ts: renew(tok), tok: tok}
+}
+
+func (s *refreshTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tok, err := s.ts.Token()
+	if err != nil {
+		return nil, err
+	}
+	s.tok = tok
+	return tok, nil
+}
+
+// refresh obtains a new token unless the current token differs from the
+// stale one, i.e. it has been refreshed already.
+func (s *refreshTokenSource) refresh(stale string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tok != nil && s.tok.AccessToken != stale {
+		return nil
+	}
+	var last oauth2.Token
+	if s.tok != nil {
+		// Keep the refresh token only, so the token is considered expired.
+		last.RefreshToken = s.tok.RefreshToken
+	}
+	ts := s.renew(&last)
+	tok, err := ts.Token()
+	if err != nil {
+		return err
+	}
+	s.ts, s.tok = ts, tok
+	return nil
+}
+
+// recoveringTokenSource allows to replace the token source of the client.
+type recoveringTokenSource struct {
+	ctx    context.Context
+	reauth Authenticator
+
+	mu   sync.RWMutex
+	base oauth2.TokenSource
+	ts   oauth2.TokenSource
+}
+
+func newRecoveringTokenSource(ctx context.Context, ts oauth2.TokenSource, reauth Authenticator) *recoveringTokenSource {
+	return &recoveringTokenSource{
+		ctx:    ctx,
+		reauth: reauth,
+		base:   ts,
+		ts:     oauth2.ReuseTokenSource(nil, ts),
+	}
+}
+
+func (s *recoveringTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.RLock()
+	ts := s.ts
+	s.mu.RUnlock()
+	return ts.Token()
+}
+
+func (s *recoveringTokenSource) unwrap() oauth2.TokenSource {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.base
+}
+
+func (s *recoveringTokenSource) Scopes() []string {
+	if sc, ok := s.unwrap().(scoper); ok {
+		return sc.Scopes()
+	}
+	return nil
+}
+
+// recover replaces the stale token with a new one.
+func (s *recoveringTokenSource) recover(stale string) error {
+	var err error
+	if rs, ok := findTokenSource[*refreshTokenSource](s.unwrap()); ok {
+		if err = rs.refresh(stale); err == nil {
+			// Drop the cached token.
+			s.mu.Lock()
+			s.ts = oauth2.ReuseTokenSource(nil, s.base)
+			s.mu.Unlock()
+			return nil
+		}
+	}
+	if s.reauth == nil {
+		if err == nil {
+			err = errors.New("token cannot be refreshed")
+		}
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if tok, err := s.ts.Token(); err == nil && tok.AccessToken != stale {
+		// Recovered by a concurrent request.
+		return nil
+	}
+	ts, err := s.reauth(s.ctx)
+	if err != nil {
+		return err
+	}
+	s.base, s.ts = ts, oauth2.ReuseTokenSource(nil, ts)
+	return nil
+}
+
+// recoveryDoer replays requests failed with invalid_token after recovering
+// the token.
+type recoveryDoer struct {
+	next Doer
+	ts   *recoveringTokenSource
+}
+
+func (d *recoveryDoer) Do(req *http.Request) (*http.Response, error) {
+	var stale string
+	if tok, err := d.ts.Token(); err == nil {
+		stale = tok.AccessToken
+	}
+	resp, err := d.next.Do(req)
+	if err != nil || !invalidToken(resp) {
+		return resp, err
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// The request cannot be replayed.
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	if err := d.ts.recover(stale); err != nil {
+		return nil, newAuthError(fmt.Errorf("unable to recover invalid token: %w", err))
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("rewind request body: %w", err)
+		}
+		req = req.Clone(req.Context())
+		req.Body = body
+	}
+	resp, err = d.next.Do(req)
+	if err != nil || !invalidToken(resp) {
+		return resp, err
+	}
+	apiErr := Error{StatusCode: resp.StatusCode, Header: resp.Header}
+	json.NewDecoder(resp.Body).Decode(&apiErr)
+	resp.Body.Close()
+	return nil, &AuthError{Kind: AuthInvalidGrant, Err: apiErr}
+}
+
+// invalidToken reports whether the response is an invalid_token error. The
+// body is left readable.
+func invalidToken(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusUnauthorized {
+		return false
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return false
+	}
+	var apiErr Error
+	return json.Unmarshal(data, &apiErr) == nil && apiErr.Type == "invalid_token"
+}
@@ -0,0 +1,115 @@
+package deviantart
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+// staleToken returns an authenticator with a token that is not expired yet
+// but is rejected by the API. It is refreshed with the server token endpoint.
+func staleToken(srv *oauthServer) Authenticator {
+	tok := &oauth2.Token{
+		AccessToken:  "stale",
+		RefreshToken: "refresh",
+		TokenType:    "Bearer",
+		Expiry:       time.Now().Add(time.Hour),
+	}
+	return FromTokenSource(configTokenSource(context.Background(), srv.config(false).oauth2Config(), tok))
+}
+
+// countingAuth returns a static token and counts calls.
+func countingAuth(calls *atomic.Int32, accessToken string) Authenticator {
+	auth := StaticToken(accessToken)
+	return func(ctx context.Context) (oauth2.TokenSource, error) {
+		calls.Add(1)
+		return auth(ctx)
+	}
+}
+
+func TestRecoveryRefresh(t *testing.T) {
+	srv := newOAuthServer(t)
+	srv.acceptOnly("access")
+	var reauth atomic.Int32
+	c := srv.client(t, staleToken(srv), WithTokenRecovery(countingAuth(&reauth, "reauthorized")))
+
+	if _, err := c.User.Whoami(); err != nil {
+		t.Fatalf("Whoami: %v", err)
+	}
+	if tokens, want := srv.apiTokens(), []string{"Bearer stale", "Bearer access"}; !slices.Equal(tokens, want) {
+		t.Errorf("API tokens = %q, want %q", tokens, want)
+	}
+	if forms := srv.tokenRequests(); len(forms) != 1 || forms[0].Get("grant_type") != "refresh_token" {
+		t.Errorf("token requests = %v, want one refresh", forms)
+	}
+	if n := reauth.Load(); n != 0 {
+		t.Errorf("reauth called %d times, want 0 once the token is refreshed", n)
+	}
+}
+
+func TestRecoveryReauth(t *testing.T) {
+	srv := newOAuthServer(t)
+	srv.acceptOnly("reauthorized")
+	srv.rejectTokenRequests("invalid_grant")
+	var reauth atomic.Int32
+	c := srv.client(t, staleToken(srv), WithTokenRecovery(countingAuth(&reauth, "reauthorized")))
+
+	if _, err := c.User.Whoami(); err != nil {
+		t.Fatalf("Whoami: %v", err)
+	}
+	if n := reauth.Load(); n != 1 {
+		t.Errorf("reauth called %d times, want 1", n)
+	}
+	if tokens, want := srv.apiTokens(), []string{"Bearer stale", "Bearer reauthorized"}; !slices.Equal(tokens, want) {
+		t.Errorf("API tokens = %q, want %q", tokens, want)
+	}
+	// The new token is used by the following requests.
+	if _, err := c.User.Whoami(); err != nil {
+		t.Fatalf("Whoami after recovery: %v", err)
+	}
+	if n := reauth.Load(); n != 1 {
+		t.Errorf("reauth called %d times, want 1", n)
+	}
+}
+
+func TestRecoveryReplayRejected(t *testing.T) {
+	srv := newOAuthServer(t)
+	srv.acceptOnly("nothing")
+	var reauth atomic.Int32
+	c := srv.client(t, StaticToken("stale"), WithTokenRecovery(countingAuth(&reauth, "reauthorized")))
+
+	_, err := c.User.Whoami()
+	var authErr *AuthError
+	if !errors.As(err, &authErr) || authErr.Kind != AuthInvalidGrant {
+		t.Fatalf("Whoami error = %v, want %v", err, AuthInvalidGrant)
+	}
+	if !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Whoami error = %v, want %v", err, ErrInvalidToken)
+	}
+	if tokens, want := srv.apiTokens(), []string{"Bearer stale", "Bearer reauthorized"}; !slices.Equal(tokens, want) {
+		t.Errorf("API tokens = %q, want %q with a single replay", tokens, want)
+	}
+}
+
+func TestRecoveryReplaysBody(t *testing.T) {
+	srv := newOAuthServer(t)
+	srv.acceptOnly("access")
+	c := srv.client(t, staleToken(srv), WithTokenRecovery(nil))
+
+	if _, err := c.Collections.Fave(uuid.MustParse("6f1b0c4e-57b8-4a0a-9a0b-2f0e5c6d7e8f")); err != nil {
+		t.Fatalf("Fave: %v", err)
+	}
+	bodies := srv.apiBodies()
+	if len(bodies) != 2 {
+		t.Fatalf("API requests = %d, want 2", len(bodies))
+	}
+	if bodies[0] == "" || bodies[1] != bodies[0] {
+		t.Errorf("replayed body = %q, want %q", bodies[1], bodies[0])
+	}
+}
@@ -0,0 +1,63 @@
+package deviantart
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+)
+
+// Response holds raw HTTP response data of a call.
+type Response struct {
+	// HTTP status code.
+	StatusCode int
+
+	// HTTP headers, including rate limit and caching hints.
+	Header http.Header
+
+	// Raw response body as received from DeviantArt.
+	Body []byte
+}
+
+type responseKey struct{}
+
+// ContextWithResponse returns a copy of ctx that makes calls made with it
+// store the received HTTP response into resp. If the call sends several
+// requests, resp holds the last one.
+//
+//	var resp deviantart.Response
+//	ctx := deviantart.ContextWithResponse(ctx, &resp)
+//	deviations, err := client.Browse.NewestContext(ctx, "", nil)
+//	log.Println(resp.StatusCode, resp.Header.Get("Cache-Control"))
+func ContextWithResponse(ctx context.Context, resp *Response) context.Context {
+	return context.WithValue(ctx, responseKey{}, resp)
+}
+
+// responseDoer stores HTTP responses for contexts created with
+// [ContextWithResponse].
+type responseDoer struct {
+	next Doer
+}
+
+func (d *responseDoer) Do(req *http.Request) (*http.Response, error) {
+	target, ok := req.Context().Value(responseKey{}).(*Response)
+	if !ok {
+		return d.next.Do(req)
+	}
+	resp, err := d.next.Do(req)
+	if err != nil {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	*target = Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	}
+	return resp, nil
+}
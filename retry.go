@@ -0,0 +1,30 @@
+package deviantart
+
+import "github.com/leonidboykov/go-deviantart/internal/ratelimit"
+
+// RetryPolicy defines when and how failed requests are retried. Requests
+// throttled with 429 Too Many Requests are always subject to retry, while 5xx
+// responses and network errors are retried for GET requests only.
+type RetryPolicy = ratelimit.Policy
+
+// RetryStats holds retry statistics of a [Client].
+type RetryStats = ratelimit.Stats
+
+// DefaultRetryPolicy returns the retry policy used by default: up to 5
+// attempts, exponential backoff from 500ms capped at 30s with 20% jitter, at
+// most 2 minutes of total waiting, Retry-After is honored.
+func DefaultRetryPolicy() RetryPolicy {
+	return ratelimit.DefaultPolicy()
+}
+
+// WithRetryPolicy sets the retry policy of the client.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *clientOptions) {
+		o.retryPolicy = policy
+	}
+}
+
+// RetryStats returns retry statistics collected since the client creation.
+func (c *Client) RetryStats() RetryStats {
+	return c.retrier.Stats()
+}
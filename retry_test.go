@@ -0,0 +1,114 @@
+package deviantart
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// throttleOnce responds with 429 to the first request of every path and
+// records bodies of all the attempts.
+type throttleOnce struct {
+	mu     sync.Mutex
+	bodies map[string][]string
+	reply  string
+}
+
+func (h *throttleOnce) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	h.mu.Lock()
+	h.bodies[r.URL.Path] = append(h.bodies[r.URL.Path], string(body))
+	first := len(h.bodies[r.URL.Path]) == 1
+	h.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if first {
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+		io.WriteString(w, `{"error":"user_api_threshold","error_description":"User API threshold exceeded.","status":"error"}`)
+		return
+	}
+	io.WriteString(w, h.reply)
+}
+
+func (h *throttleOnce) attempts(t *testing.T, path string) []string {
+	t.Helper()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	bodies := h.bodies[path]
+	if len(bodies) != 2 {
+		t.Fatalf("%s: got %d attempts, want 2", path, len(bodies))
+	}
+	return bodies
+}
+
+func newThrottledClient(t *testing.T, reply string) (*Client, *throttleOnce) {
+	t.Helper()
+	h := &throttleOnce{bodies: make(map[string][]string), reply: reply}
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	c, err := NewClient(StaticToken("token"), WithBaseURL(srv.URL), WithRetryPolicy(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, h
+}
+
+func TestRetryReplaysMultipartBody(t *testing.T) {
+	c, h := newThrottledClient(t, `{"status":"success","itemid":42}`)
+	files := fstest.MapFS{"art.png": {Data: []byte("image data")}}
+	file, err := files.Open("art.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	resp, err := c.Stash.Submit(&StashSubmitParams{Title: "Art"}, file)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if resp.ItemID != 42 {
+		t.Errorf("ItemID = %d, want 42", resp.ItemID)
+	}
+
+	bodies := h.attempts(t, "/stash/submit")
+	if bodies[1] != bodies[0] {
+		t.Errorf("retried body differs from the first one:\n%q\n%q", bodies[1], bodies[0])
+	}
+	for _, want := range []string{"image data", `name="title"`, "Art"} {
+		if !strings.Contains(bodies[1], want) {
+			t.Errorf("retried body lacks %q: %q", want, bodies[1])
+		}
+	}
+}
+
+func TestRetryReplaysFormBody(t *testing.T) {
+	c, h := newThrottledClient(t, `{"success":true,"favourites":7}`)
+	deviationID := uuid.MustParse("6f1b0c4e-57b8-4a0a-9a0b-2f0e5c6d7e8f")
+
+	faves, err := c.Collections.Fave(deviationID)
+	if err != nil {
+		t.Fatalf("Fave: %v", err)
+	}
+	if faves != 7 {
+		t.Errorf("favourites = %d, want 7", faves)
+	}
+
+	bodies := h.attempts(t, "/collections/fave")
+	if !strings.HasPrefix(bodies[0], "deviationid=") {
+		t.Fatalf("first body = %q, want deviationid form", bodies[0])
+	}
+	if bodies[1] != bodies[0] {
+		t.Errorf("retried body = %q, want %q", bodies[1], bodies[0])
+	}
+}
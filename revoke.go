@@ -0,0 +1,142 @@
+package deviantart
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/dghubble/sling"
+	"golang.org/x/oauth2"
+)
+
+const revokeURL = "https://www.deviantart.com/oauth2/revoke"
+
+// WithRevokeURL overrides the DeviantArt token revocation endpoint, e.g. to
+// use a stand-in authorization server.
+func WithRevokeURL(url string) Option {
+	return func(o *clientOptions) {
+		o.revokeURL = url
+	}
+}
+
+// Revoke revokes the access token of the client on DeviantArt side. If
+// refreshOnly is true, only the refresh token is revoked and the access token
+// keeps working until it expires.
+func (c *Client) Revoke(refreshOnly bool) error {
+	return c.RevokeContext(context.Background(), refreshOnly)
+}
+
+// RevokeContext is like [Client.Revoke] but uses ctx for the request.
+func (c *Client) RevokeContext(ctx context.Context, refreshOnly bool) error {
+	type revokeParams struct {
+		Token       string `url:"token"`
+		RefreshOnly bool   `url:"revoke_refresh_only,omitempty"`
+	}
+	tok, err := c.tokenSource.Token()
+	if err != nil {
+		return fmt.Errorf("unable to revoke token: %w", newAuthError(err))
+	}
+	var (
+		success StatusResponse
+		failure Error
+	)
+	params := &revokeParams{Token: tok.AccessToken, RefreshOnly: refreshOnly}
+	_, err = receive(ctx, sling.New().Doer(c.httpClient).Post(c.revokeURL).BodyForm(params), &success, &failure)
+	if err := relevantError(err, failure); err != nil {
+		return fmt.Errorf("unable to revoke token: %w", err)
+	}
+	return nil
+}
+
+// ErrLoggedOut is returned by requests of a client after [Client.Logout].
+var ErrLoggedOut = errors.New("client is logged out")
+
+// Logout revokes the access token of the client and clears the [TokenStore]
+// the token came from, if any. The store is cleared even if the token cannot
+// be revoked. A token that is already invalid is considered revoked.
+//
+// The client stops using the token, so further requests fail with
+// [AuthError] matching [ErrLoggedOut]. Create a new client to log in again.
+func (c *Client) Logout() error {
+	return c.LogoutContext(context.Background())
+}
+
+// LogoutContext is like [Client.Logout] but uses ctx for the request.
+func (c *Client) LogoutContext(ctx context.Context) error {
+	err := c.RevokeContext(ctx, false)
+	if errors.Is(err, ErrInvalidToken) {
+		err = nil
+	}
+	c.session.close()
+	if store := tokenStoreOf(c.tokenSource); store != nil {
+		if clearErr := store.Clear(); clearErr != nil {
+			err = errors.Join(err, fmt.Errorf("unable to clear token store: %w", clearErr))
+		}
+	}
+	return err
+}
+
+// sessionTokenSource caches tokens used by the client until it logs out.
+type sessionTokenSource struct {
+	base   oauth2.TokenSource
+	cached oauth2.TokenSource
+	closed atomic.Bool
+}
+
+func newSessionTokenSource(base, cached oauth2.TokenSource) *sessionTokenSource {
+	return &sessionTokenSource{base: base, cached: cached}
+}
+
+func (s *sessionTokenSource) Token() (*oauth2.Token, error) {
+	if s.closed.Load() {
+		return nil, &AuthError{Kind: AuthInvalidGrant, Err: ErrLoggedOut}
+	}
+	return s.cached.Token()
+}
+
+// close drops the cached token, so it is never sent again.
+func (s *sessionTokenSource) close() {
+	s.closed.Store(true)
+}
+
+func (s *sessionTokenSource) unwrap() oauth2.TokenSource {
+	return s.base
+}
+
+func (s *sessionTokenSource) Scopes() []string {
+	if sc, ok := s.base.(scoper); ok {
+		return sc.Scopes()
+	}
+	return nil
+}
+
+// wrappedTokenSource is implemented by token sources that wrap another one.
+type wrappedTokenSource interface {
+	unwrap() oauth2.TokenSource
+}
+
+// tokenStoreOf returns the store the token source saves tokens to.
+func tokenStoreOf(ts oauth2.TokenSource) TokenStore {
+	if s, ok := findTokenSource[*storeTokenSource](ts); ok {
+		return s.store
+	}
+	return nil
+}
+
+// findTokenSource returns the first token source of type T in the chain of
+// wrapped token sources.
+func findTokenSource[T oauth2.TokenSource](ts oauth2.TokenSource) (T, bool) {
+	for ts != nil {
+		if t, ok := ts.(T); ok {
+			return t, true
+		}
+		w, ok := ts.(wrappedTokenSource)
+		if !ok {
+			break
+		}
+		ts = w.unwrap()
+	}
+	var zero T
+	return zero, false
+}
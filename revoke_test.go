@@ -0,0 +1,85 @@
+package deviantart
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestRevoke(t *testing.T) {
+	srv := newOAuthServer(t)
+	c := srv.client(t, StaticToken("access"))
+
+	if err := c.Revoke(true); err != nil {
+		t.Fatalf("Revoke(true): %v", err)
+	}
+	if err := c.Revoke(false); err != nil {
+		t.Fatalf("Revoke(false): %v", err)
+	}
+
+	forms := srv.revokeForms()
+	if len(forms) != 2 {
+		t.Fatalf("revoke endpoint called %d times, want 2", len(forms))
+	}
+	for _, form := range forms {
+		if token := form.Get("token"); token != "access" {
+			t.Errorf("token = %q, want %q", token, "access")
+		}
+	}
+	if v := forms[0].Get("revoke_refresh_only"); v != "true" {
+		t.Errorf("revoke_refresh_only = %q, want %q", v, "true")
+	}
+	if forms[1].Has("revoke_refresh_only") {
+		t.Errorf("revoke_refresh_only is sent to revoke both tokens: %v", forms[1])
+	}
+}
+
+func TestRevokeInvalidToken(t *testing.T) {
+	srv := newOAuthServer(t)
+	srv.rejectRevoke()
+	c := srv.client(t, StaticToken("access"))
+
+	if err := c.Revoke(false); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Revoke error = %v, want %v", err, ErrInvalidToken)
+	}
+	if err := c.Logout(); err != nil {
+		t.Errorf("Logout of invalid token: %v", err)
+	}
+}
+
+func TestLogout(t *testing.T) {
+	srv := newOAuthServer(t)
+	store := NewMemoryTokenStore()
+	store.Save(&oauth2.Token{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		TokenType:    "Bearer",
+		Expiry:       time.Now().Add(time.Hour),
+	})
+	conf := srv.config(false)
+	conf.TokenStore = store
+	c := srv.client(t, conf.Authenticator())
+
+	if _, err := c.User.Whoami(); err != nil {
+		t.Fatalf("Whoami before logout: %v", err)
+	}
+	if err := c.Logout(); err != nil {
+		t.Fatalf("Logout: %v", err)
+	}
+	if forms := srv.revokeForms(); len(forms) != 1 || forms[0].Get("token") != "access" {
+		t.Errorf("revoke requests = %v, want one for the access token", forms)
+	}
+	if _, err := store.Load(); !errors.Is(err, ErrNoToken) {
+		t.Errorf("token store after logout: %v, want %v", err, ErrNoToken)
+	}
+
+	_, err := c.User.Whoami()
+	if !errors.Is(err, ErrLoggedOut) {
+		t.Errorf("Whoami after logout error = %v, want %v", err, ErrLoggedOut)
+	}
+	if tokens := srv.apiTokens(); len(tokens) != 1 {
+		t.Errorf("API requests = %q, want no requests after logout", tokens)
+	}
+}
@@ -0,0 +1,274 @@
+package deviantart
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// operation describes scopes required by an API method. Path is relative to
+// the API base URL, "*" matches any single path segment.
+type operation struct {
+	name   string
+	path   string
+	scopes []string
+}
+
+// operations lists scopes required by API methods as documented on each of
+// them. Methods of [FoldersService] are listed for both collections and
+// galleries.
+var operations = append([]operation{
+	{"Browse.DailyDeviations", "browse/dailydeviations", []string{BrowseScope}},
+	{"Browse.DeviantsYouWatch", "browse/deviantsyouwatch", []string{BrowseScope}},
+	{"Browse.MoreLikeThisPreview", "browse/morelikethis/preview", []string{BrowseScope, BrowseMLTScope}},
+	{"Browse.Newest", "browse/newest", []string{BrowseScope}},
+	{"Browse.Popular", "browse/popular", []string{BrowseScope}},
+	{"Browse.PostsDeviantsYouWatch", "browse/posts/deviantsyouwatch", []string{BrowseScope}},
+	{"Browse.Recommended", "browse/recommended", []string{BrowseScope}},
+	{"Browse.Tags", "browse/tags", []string{BrowseScope}},
+	{"Browse.TagsOffset", "browse/tags", []string{BrowseScope}},
+	{"Browse.TagsSearch", "browse/tags/search", []string{BrowseScope}},
+	{"Browse.Topic", "browse/topic", []string{BrowseScope}},
+	{"Browse.Topics", "browse/topics", []string{BrowseScope}},
+	{"Browse.TopTopics", "browse/toptopics", []string{BrowseScope}},
+	{"Browse.UserJournals", "browse/user/journals", []string{BrowseScope}},
+	{"Browse.Search", "browse/newest", []string{BrowseScope}},
+
+	{"Collections.Fave", "collections/fave", []string{BrowseScope, CollectionScope}},
+	{"Collections.Unfave", "collections/unfave", []string{BrowseScope, CollectionScope}},
+
+	{"Comments.CommentSiblings", "comments/*/siblings", []string{BrowseScope}},
+	{"Comments.DeviationComments", "comments/deviation/*", []string{BrowseScope}},
+	{"Comments.ProfileComments", "comments/profile/*", []string{BrowseScope}},
+	{"Comments.StatusComments", "comments/status/*", []string{BrowseScope}},
+	{"Comments.CommentDeviation", "comments/post/deviation/*", []string{BrowseScope, CommentPostScope}},
+	{"Comments.CommentProfile", "comments/post/profile/*", []string{BrowseScope, CommentPostScope}},
+	{"Comments.CommentStatus", "comments/post/status/*", []string{BrowseScope, CommentPostScope}},
+
+	{"Deviation.Deviation", "deviation/*", []string{BrowseScope}},
+	{"Deviation.Content", "deviation/content", []string{BrowseScope}},
+	{"Deviation.Download", "deviation/download/*", []string{BrowseScope}},
+	{"Deviation.Edit", "deviation/edit/*", []string{StashScope, PublishScope}},
+	{"Deviation.EmbeddedContent", "deviation/embeddedcontent", []string{BrowseScope}},
+	{"Deviation.Metadata", "deviation/metadata", []string{BrowseScope}},
+	{"Deviation.WhoFaved", "deviation/whofaved", []string{BrowseScope}},
+	{"Deviation.CreateJournal", "deviation/journal/create", []string{UserManageScope}},
+	{"Deviation.UpdateJournal", "deviation/journal/update/*", []string{UserManageScope}},
+	{"Deviation.CreateLiterature", "deviation/literature/create", []string{UserManageScope}},
+	{"Deviation.UpdateLiterature", "deviation/literature/update/*", []string{UserManageScope}},
+
+	{"Messages.Delete", "messages/delete", []string{MessageScope}},
+	{"Messages.Feed", "messages/feed", []string{MessageScope}},
+	{"Messages.Feedback", "messages/feedback", []string{MessageScope}},
+	{"Messages.StackFeedback", "messages/feedback/*", []string{MessageScope}},
+	{"Messages.Mentions", "messages/mentions", []string{MessageScope}},
+	{"Messages.StackMentions", "messages/mentions/*", []string{MessageScope}},
+
+	{"Stash.Stack", "stash/*", []string{StashScope}},
+	{"Stash.StackContents", "stash/*/contents", []string{StashScope}},
+	{"Stash.Delete", "stash/delete", []string{StashScope}},
+	{"Stash.Delta", "stash/delta", []string{StashScope}},
+	{"Stash.Item", "stash/item/*", []string{StashScope}},
+	{"Stash.Move", "stash/move/*", []string{StashScope}},
+	{"Stash.Position", "stash/position/*", []string{StashScope}},
+	{"Stash.Publish", "stash/publish", []string{StashScope, PublishScope}},
+	{"Stash.Userdata", "stash/publish/userdata", []string{StashScope, PublishScope}},
+	{"Stash.Space", "stash/space", []string{StashScope}},
+	{"Stash.Submit", "stash/submit", []string{StashScope}},
+	{"Stash.Update", "stash/update/*", []string{StashScope}},
+
+	{"User.DAmnToken", "user/damntoken", []string{UserScope}},
+	{"User.Tiers", "user/tiers/*", []string{UserScope}},
+	{"User.Watchers", "user/watchers/*", []string{BrowseScope}},
+	{"User.Whoami", "user/whoami", []string{UserScope}},
+	{"User.Whois", "user/whois", []string{BrowseScope}},
+	{"User.Profile", "user/profile/*", []string{BrowseScope}},
+	{"User.Posts", "user/profile/posts", []string{BrowseScope}},
+	{"User.UpdateProfile", "user/profile/update", []string{UserManageScope}},
+	{"User.Status", "user/statuses/*", []string{BrowseScope}},
+	{"User.Statuses", "user/statuses", []string{BrowseScope}},
+	{"User.PostStatus", "user/statuses/post", []string{BrowseScope, UserManageScope}},
+	{"User.Friends.Get", "user/friends/*", []string{BrowseScope}},
+	{"User.Friends.Search", "user/friends/search", []string{BrowseScope}},
+	{"User.Friends.Watch", "user/friends/watch/*", []string{BrowseScope, UserManageScope}},
+	{"User.Friends.Unwatch", "user/friends/unwatch/*", []string{BrowseScope, UserManageScope}},
+	{"User.Friends.Watching", "user/friends/watching/*", []string{BrowseScope, UserScope}},
+}, append(folderOperations("Collections", "collections"), folderOperations("Gallery", "gallery")...)...)
+
+func folderOperations(service, base string) []operation {
+	return []operation{
+		{service + ".Folder", base + "/*", []string{BrowseScope}},
+		{service + ".All", base + "/all", []string{BrowseScope}},
+		{service + ".Folders", base + "/folders", []string{BrowseScope}},
+		{service + ".CopyDeviations", base + "/folders/copy_deviations", []string{BrowseScope, CollectionScope}},
+		{service + ".Create", base + "/folders/create", []string{BrowseScope, CollectionScope}},
+		{service + ".MoveDeviations", base + "/folders/move_destination", []string{BrowseScope, CollectionScope}},
+		{service + ".Remove", base + "/folders/remove/*", []string{BrowseScope, CollectionScope}},
+		{service + ".RemoveDeviations", base + "/folders/remove_deviations", []string{BrowseScope, CollectionScope}},
+		{service + ".Update", base + "/folders/update", []string{BrowseScope, CollectionScope}},
+		{service + ".UpdateDeviationOrder", base + "/folders/update_deviation_order", []string{BrowseScope, CollectionScope}},
+		{service + ".UpdateOrder", base + "/folders/update_order", []string{BrowseScope, CollectionScope}},
+	}
+}
+
+// RequiredScopes returns scopes required by the operation named after the
+// service and method, such as "Stash.Publish" or "User.Friends.Get".
+func RequiredScopes(operation string) ([]string, bool) {
+	for _, op := range operations {
+		if op.name == operation {
+			return slices.Clone(op.scopes), true
+		}
+	}
+	return nil, false
+}
+
+// MinimalScopes returns the smallest set of scopes that allows all the
+// operations, see [RequiredScopes] for operation names.
+func MinimalScopes(names ...string) ([]string, error) {
+	var scopes []string
+	for _, name := range names {
+		required, ok := RequiredScopes(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown operation %q", name)
+		}
+		scopes = append(scopes, required...)
+	}
+	slices.Sort(scopes)
+	return slices.Compact(scopes), nil
+}
+
+// matchOperation returns the operation for the path relative to the API base
+// URL. Literal segments take precedence over wildcards.
+func matchOperation(path string) (operation, bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	var (
+		found operation
+		best  = -1
+	)
+	for _, op := range operations {
+		pattern := strings.Split(op.path, "/")
+		if len(pattern) != len(segments) {
+			continue
+		}
+		literals := 0
+		for i, s := range pattern {
+			if s == "*" {
+				continue
+			}
+			if s != segments[i] {
+				literals = -1
+				break
+			}
+			literals++
+		}
+		if literals > best {
+			found, best = op, literals
+		}
+	}
+	return found, best >= 0
+}
+
+// MissingScopeError is returned when the token lacks scopes required by the
+// operation. It matches [ErrInsufficientScope].
+type MissingScopeError struct {
+	Operation string
+	Missing   []string
+}
+
+func (e *MissingScopeError) Error() string {
+	return fmt.Sprintf("%s requires missing scopes: %s", e.Operation, strings.Join(e.Missing, ", "))
+}
+
+func (e *MissingScopeError) Is(target error) bool {
+	return target == ErrInsufficientScope
+}
+
+// WithScopeCheck makes the client fail requests that need scopes the token
+// was not granted with [MissingScopeError] before sending them. Requests are
+// not checked while granted scopes are unknown, see [Client.GrantedScopes].
+func WithScopeCheck() Option {
+	return func(o *clientOptions) {
+		o.checkScopes = true
+	}
+}
+
+// scoper is implemented by token sources that know scopes requested for the
+// token.
+type scoper interface {
+	Scopes() []string
+}
+
+// scopedTokenSource is a token source with requested scopes.
+type scopedTokenSource struct {
+	oauth2.TokenSource
+	scopes []string
+}
+
+func (s *scopedTokenSource) Scopes() []string {
+	return s.scopes
+}
+
+func (s *scopedTokenSource) unwrap() oauth2.TokenSource {
+	return s.TokenSource
+}
+
+// grantedScopes returns scopes of the token from the token response or, if
+// missing, the scopes requested by the token source.
+func grantedScopes(ts oauth2.TokenSource) ([]string, error) {
+	tok, err := ts.Token()
+	if err != nil {
+		return nil, err
+	}
+	if scope, ok := tok.Extra("scope").(string); ok && scope != "" {
+		return splitScopes(scope), nil
+	}
+	if s, ok := ts.(scoper); ok {
+		return slices.Clone(s.Scopes()), nil
+	}
+	return nil, nil
+}
+
+// GrantedScopes returns scopes granted to the client token, either reported
+// by the token endpoint or requested by [AuthorizationCodeConfig]. It returns
+// nil if the scopes are unknown.
+func (c *Client) GrantedScopes() ([]string, error) {
+	scopes, err := grantedScopes(c.tokenSource)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get token: %w", newAuthError(err))
+	}
+	return scopes, nil
+}
+
+// scopeDoer checks the token scopes before sending requests.
+type scopeDoer struct {
+	next     Doer
+	ts       oauth2.TokenSource
+	basePath string
+}
+
+func (d *scopeDoer) Do(req *http.Request) (*http.Response, error) {
+	op, ok := matchOperation(strings.TrimPrefix(req.URL.Path, d.basePath))
+	if !ok {
+		return d.next.Do(req)
+	}
+	granted, err := grantedScopes(d.ts)
+	if err != nil {
+		return nil, newAuthError(err)
+	}
+	if granted == nil {
+		return d.next.Do(req)
+	}
+	var missing []string
+	for _, scope := range op.scopes {
+		if !slices.Contains(granted, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, &MissingScopeError{Operation: op.name, Missing: missing}
+	}
+	return d.next.Do(req)
+}
@@ -0,0 +1,145 @@
+package deviantart
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestMatchOperation(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"user/friends/watch/someone", "User.Friends.Watch"},
+		{"user/friends/unwatch/someone", "User.Friends.Unwatch"},
+		{"user/friends/watching/someone", "User.Friends.Watching"},
+		{"user/friends/search", "User.Friends.Search"},
+		{"user/friends/someone", "User.Friends.Get"},
+		{"/stash/publish/userdata", "Stash.Userdata"},
+		{"stash/123/contents", "Stash.StackContents"},
+	}
+	for _, tt := range tests {
+		op, ok := matchOperation(tt.path)
+		if !ok || op.name != tt.want {
+			t.Errorf("matchOperation(%q) = %q, %v, want %q", tt.path, op.name, ok, tt.want)
+		}
+	}
+	if op, ok := matchOperation("unknown/endpoint"); ok {
+		t.Errorf("matchOperation of unknown path = %q", op.name)
+	}
+}
+
+func TestRequiredScopes(t *testing.T) {
+	tests := []struct {
+		operation string
+		want      []string
+	}{
+		{"User.Friends.Watch", []string{BrowseScope, UserManageScope}},
+		{"User.Friends.Unwatch", []string{BrowseScope, UserManageScope}},
+		{"User.Friends.Watching", []string{BrowseScope, UserScope}},
+		{"Stash.Publish", []string{StashScope, PublishScope}},
+	}
+	for _, tt := range tests {
+		got, ok := RequiredScopes(tt.operation)
+		if !ok || !slices.Equal(got, tt.want) {
+			t.Errorf("RequiredScopes(%q) = %q, %v, want %q", tt.operation, got, ok, tt.want)
+		}
+	}
+}
+
+func TestMinimalScopes(t *testing.T) {
+	got, err := MinimalScopes("User.Friends.Watch", "User.Friends.Watching", "Browse.Newest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{BrowseScope, UserScope, UserManageScope}
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("MinimalScopes = %q, want %q", got, want)
+	}
+	if _, err := MinimalScopes("User.Unknown"); err == nil {
+		t.Error("MinimalScopes accepted unknown operation")
+	}
+}
+
+// TestOperationsCoverServices makes sure every API method of the services is
+// listed in operations.
+func TestOperationsCoverServices(t *testing.T) {
+	var check func(prefix string, typ reflect.Type)
+	check = func(prefix string, typ reflect.Type) {
+		for i := range typ.NumMethod() {
+			name := typ.Method(i).Name
+			if strings.HasSuffix(name, "Context") || strings.HasSuffix(name, "Iter") {
+				continue
+			}
+			if _, ok := RequiredScopes(prefix + name); !ok {
+				t.Errorf("operation %q is not listed in operations", prefix+name)
+			}
+		}
+		for i := range typ.Elem().NumField() {
+			field := typ.Elem().Field(i)
+			if field.IsExported() && !field.Anonymous && strings.HasSuffix(field.Type.String(), "Service") {
+				check(prefix+field.Name+".", field.Type)
+			}
+		}
+	}
+
+	client := reflect.TypeFor[Client]()
+	services := 0
+	for i := range client.NumField() {
+		field := client.Field(i)
+		if field.IsExported() && field.Type.Kind() == reflect.Pointer && strings.HasSuffix(field.Type.Elem().Name(), "Service") {
+			check(field.Name+".", field.Type)
+			services++
+		}
+	}
+	if services == 0 {
+		t.Fatal("no services found in Client")
+	}
+}
+
+func TestScopeCheck(t *testing.T) {
+	srv := newOAuthServer(t)
+	tok := (&oauth2.Token{AccessToken: "access", TokenType: "Bearer"}).WithExtra(map[string]any{"scope": "browse user"})
+	c, err := NewClient(FromTokenSource(oauth2.StaticTokenSource(tok)), WithBaseURL(srv.URL), WithScopeCheck())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.User.Whoami(); err != nil {
+		t.Errorf("Whoami with granted scopes: %v", err)
+	}
+	if err := c.Placebo(); err != nil {
+		t.Errorf("Placebo that is not listed in operations: %v", err)
+	}
+	_, err = c.Stash.Space()
+	var scopeErr *MissingScopeError
+	if !errors.As(err, &scopeErr) || !errors.Is(err, ErrInsufficientScope) {
+		t.Fatalf("Space error = %v, want %T", err, scopeErr)
+	}
+	if scopeErr.Operation != "Stash.Space" || !slices.Equal(scopeErr.Missing, []string{StashScope}) {
+		t.Errorf("Space error = %+v, want missing %q of Stash.Space", scopeErr, StashScope)
+	}
+	if n := len(srv.apiTokens()); n != 2 {
+		t.Errorf("API requests = %d, want 2 without the rejected one", n)
+	}
+}
+
+func TestScopeCheckUnknownScopes(t *testing.T) {
+	srv := newOAuthServer(t)
+	c, err := NewClient(StaticToken("access"), WithBaseURL(srv.URL), WithScopeCheck())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Stash.Space(); err != nil {
+		t.Errorf("Space with unknown scopes: %v", err)
+	}
+	if n := len(srv.apiTokens()); n != 1 {
+		t.Errorf("API requests = %d, want 1", n)
+	}
+}
@@ -1,7 +1,9 @@
 package deviantart
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"strconv"
 
 	"github.com/dghubble/sling"
@@ -73,11 +75,16 @@ type StashStats struct {
 //
 //   - stash
 func (s *StashService) Stack(stackID int64) (StashMetadata, error) {
+	return s.StackContext(context.Background(), stackID)
+}
+
+// StackContext is like [StashService.Stack] but uses ctx for the request.
+func (s *StashService) StackContext(ctx context.Context, stackID int64) (StashMetadata, error) {
 	var (
 		success StashMetadata
 		failure Error
 	)
-	_, err := s.sling.New().Get(strconv.FormatInt(stackID, 10)).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get(strconv.FormatInt(stackID, 10)), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return StashMetadata{}, fmt.Errorf("unable to fetch stack metadata: %w", err)
 	}
@@ -105,17 +112,34 @@ const RootStackID = 0
 //
 //   - stash
 func (s *StashService) StackContents(stackID int64, params *StackContentsParams) (OffsetResponse[StashMetadata], error) {
+	return s.StackContentsContext(context.Background(), stackID, params)
+}
+
+// StackContentsContext is like [StashService.StackContents] but uses ctx for the request.
+func (s *StashService) StackContentsContext(ctx context.Context, stackID int64, params *StackContentsParams) (OffsetResponse[StashMetadata], error) {
 	var (
 		success OffsetResponse[StashMetadata]
 		failure Error
 	)
-	_, err := s.sling.New().Get(strconv.FormatInt(stackID, 10)+"/contents").QueryStruct(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get(strconv.FormatInt(stackID, 10)+"/contents").QueryStruct(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return OffsetResponse[StashMetadata]{}, fmt.Errorf("unable to fetch stack contents: %w", err)
 	}
 	return success, nil
 }
 
+// StackContentsIter iterates over all pages of [StashService.StackContents].
+func (s *StashService) StackContentsIter(ctx context.Context, stackID int64, params *StackContentsParams, opts *IterOptions) iter.Seq2[StashMetadata, error] {
+	return iterOffset(ctx, opts, iterOrigin("Stash.StackContents", stackID, params), func(ctx context.Context, page *OffsetParams) (OffsetResponse[StashMetadata], error) {
+		p := StackContentsParams{}
+		if params != nil {
+			p = *params
+		}
+		p.Offset, p.Limit = uint16(page.Offset), uint8(page.Limit)
+		return s.StackContentsContext(ctx, stackID, &p)
+	})
+}
+
 type deleteParams struct {
 	ItemID int64 `json:"itemid"`
 }
@@ -129,12 +153,17 @@ type deleteParams struct {
 //
 //   - stash
 func (s *StashService) Delete(itemID int64) (bool, error) {
+	return s.DeleteContext(context.Background(), itemID)
+}
+
+// DeleteContext is like [StashService.Delete] but uses ctx for the request.
+func (s *StashService) DeleteContext(ctx context.Context, itemID int64) (bool, error) {
 	var (
 		// TODO: Check error_description.
 		success map[string]any
 		failure Error
 	)
-	_, err := s.sling.New().Post("delete").BodyForm(deleteParams{ItemID: itemID}).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Post("delete").BodyForm(deleteParams{ItemID: itemID}), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return false, fmt.Errorf("unable to delete item: %w", err)
 	}
@@ -142,16 +171,19 @@ func (s *StashService) Delete(itemID int64) (bool, error) {
 }
 
 type StashDeltaResponse struct {
-	Cursor     string `json:"cursor"`
-	HasMore    bool   `json:"has_more"`
-	NextOffset int    `json:"next_offset"`
-	Reset      bool   `json:"reset"`
-	Entries    []struct {
-		ItemID   int64         `json:"itemid,omitempty"`
-		StackID  int64         `json:"stackid,omitempty"`
-		Metadata StashMetadata `json:"stash_metadata"`
-		Position int           `json:"position,omitempty"`
-	} `json:"entries,omitempty"`
+	Cursor     string            `json:"cursor"`
+	HasMore    bool              `json:"has_more"`
+	NextOffset int               `json:"next_offset"`
+	Reset      bool              `json:"reset"`
+	Entries    []StashDeltaEntry `json:"entries,omitempty"`
+}
+
+// StashDeltaEntry is a stack or an item changed since the last delta call.
+type StashDeltaEntry struct {
+	ItemID   int64         `json:"itemid,omitempty"`
+	StackID  int64         `json:"stackid,omitempty"`
+	Metadata StashMetadata `json:"stash_metadata"`
+	Position int           `json:"position,omitempty"`
 }
 
 type StashDeltaParams struct {
@@ -198,17 +230,41 @@ type StashDeltaParams struct {
 //
 //   - stash
 func (s *StashService) Delta(params *StashDeltaParams) (StashDeltaResponse, error) {
+	return s.DeltaContext(context.Background(), params)
+}
+
+// DeltaContext is like [StashService.Delta] but uses ctx for the request.
+func (s *StashService) DeltaContext(ctx context.Context, params *StashDeltaParams) (StashDeltaResponse, error) {
 	var (
 		success StashDeltaResponse
 		failure Error
 	)
-	_, err := s.sling.New().Get("delta").QueryStruct(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("delta").QueryStruct(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return StashDeltaResponse{}, fmt.Errorf("unable to fetch felta: %w", err)
 	}
 	return success, nil
 }
 
+// DeltaIter iterates over all pages of [StashService.Delta] for the cursor set
+// in params. The cursor for the next delta call and the reset flag are only
+// reported by [StashService.Delta].
+func (s *StashService) DeltaIter(ctx context.Context, params *StashDeltaParams, opts *IterOptions) iter.Seq2[StashDeltaEntry, error] {
+	return iterOffset(ctx, opts, iterOrigin("Stash.Delta", params), func(ctx context.Context, page *OffsetParams) (OffsetResponse[StashDeltaEntry], error) {
+		p := StashDeltaParams{}
+		if params != nil {
+			p = *params
+		}
+		p.Offset, p.Limit = int(page.Offset), int(page.Limit)
+		delta, err := s.DeltaContext(ctx, &p)
+		return OffsetResponse[StashDeltaEntry]{
+			Results:    delta.Entries,
+			HasMore:    delta.HasMore,
+			NextOffset: uint32(delta.NextOffset),
+		}, err
+	})
+}
+
 type StashMoveResponse struct {
 	Target  StashMetadata   `json:"target"`
 	Changes []StashMetadata `json:"changes"`
@@ -233,12 +289,17 @@ type moveParams struct {
 //
 //   - stash
 func (s *StashService) Move(stackID, targetID int64) (StashMoveResponse, error) {
+	return s.MoveContext(context.Background(), stackID, targetID)
+}
+
+// MoveContext is like [StashService.Move] but uses ctx for the request.
+func (s *StashService) MoveContext(ctx context.Context, stackID, targetID int64) (StashMoveResponse, error) {
 	var (
 		success StashMoveResponse
 		failure Error
 	)
 	params := &moveParams{TargetID: targetID}
-	_, err := s.sling.New().Post("move/").Path(strconv.FormatInt(stackID, 10)).BodyForm(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Post("move/").Path(strconv.FormatInt(stackID, 10)).BodyForm(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return StashMoveResponse{}, fmt.Errorf("unable to move stash: %w", err)
 	}
@@ -254,6 +315,11 @@ func (s *StashService) Move(stackID, targetID int64) (StashMoveResponse, error)
 //
 //   - stash
 func (s *StashService) Position(stackID, position int64) (bool, error) {
+	return s.PositionContext(context.Background(), stackID, position)
+}
+
+// PositionContext is like [StashService.Position] but uses ctx for the request.
+func (s *StashService) PositionContext(ctx context.Context, stackID, position int64) (bool, error) {
 	type positionParams struct {
 		Position int64 `url:"position"`
 	}
@@ -263,7 +329,7 @@ func (s *StashService) Position(stackID, position int64) (bool, error) {
 	)
 	stackPath := strconv.FormatInt(stackID, 10)
 	params := &positionParams{Position: position}
-	_, err := s.sling.New().Post("position/").Path(stackPath).BodyForm(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Post("position/").Path(stackPath).BodyForm(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return false, fmt.Errorf("unable to change stash position: %w", err)
 	}
@@ -285,11 +351,16 @@ type StashUserdata struct {
 //   - stash
 //   - publish
 func (s *StashService) Userdata() (StashUserdata, error) {
+	return s.UserdataContext(context.Background())
+}
+
+// UserdataContext is like [StashService.Userdata] but uses ctx for the request.
+func (s *StashService) UserdataContext(ctx context.Context) (StashUserdata, error) {
 	var (
 		success StashUserdata
 		failure Error
 	)
-	_, err := s.sling.New().Get("publish/userdata").Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("publish/userdata"), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return StashUserdata{}, fmt.Errorf("unable to fetch userdata: %w", err)
 	}
@@ -316,11 +387,16 @@ func (s StashSpace) String() string {
 //
 //   - stash
 func (s *StashService) Space() (StashSpace, error) {
+	return s.SpaceContext(context.Background())
+}
+
+// SpaceContext is like [StashService.Space] but uses ctx for the request.
+func (s *StashService) SpaceContext(ctx context.Context) (StashSpace, error) {
 	var (
 		success StashSpace
 		failure Error
 	)
-	_, err := s.sling.New().Get("space").Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("space"), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return StashSpace{}, fmt.Errorf("unable to fetch sta.sh space: %w", err)
 	}
@@ -341,12 +417,17 @@ type StashUpdateParams struct {
 //
 //   - stash
 func (s *StashService) Update(stackID int64, params *StashUpdateParams) (bool, error) {
+	return s.UpdateContext(context.Background(), stackID, params)
+}
+
+// UpdateContext is like [StashService.Update] but uses ctx for the request.
+func (s *StashService) UpdateContext(ctx context.Context, stackID int64, params *StashUpdateParams) (bool, error) {
 	var (
 		success map[string]any
 		failure Error
 	)
 	stackPath := strconv.FormatInt(stackID, 10)
-	_, err := s.sling.New().Post("update/").Path(stackPath).BodyForm(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Post("update/").Path(stackPath).BodyForm(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return false, fmt.Errorf("unable to update stack: %w", err)
 	}
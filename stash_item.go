@@ -1,6 +1,7 @@
 package deviantart
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 )
@@ -28,11 +29,16 @@ type ItemParams struct {
 //
 //   - stash
 func (s *StashService) Item(itemID int64, params *ItemParams) (StashItem, error) {
+	return s.ItemContext(context.Background(), itemID, params)
+}
+
+// ItemContext is like [StashService.Item] but uses ctx for the request.
+func (s *StashService) ItemContext(ctx context.Context, itemID int64, params *ItemParams) (StashItem, error) {
 	var (
 		success StashItem
 		failure Error
 	)
-	_, err := s.sling.New().Post("item/").Path(strconv.FormatInt(itemID, 10)).QueryStruct(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Post("item/").Path(strconv.FormatInt(itemID, 10)).QueryStruct(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return StashItem{}, fmt.Errorf("unable to fetch item: %w", err)
 	}
@@ -1,6 +1,7 @@
 package deviantart
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -104,11 +105,16 @@ type StashPublishResponse struct {
 //   - stash
 //   - publish
 func (s *StashService) Publish(params StashPublishParams) (StashPublishResponse, error) {
+	return s.PublishContext(context.Background(), params)
+}
+
+// PublishContext is like [StashService.Publish] but uses ctx for the request.
+func (s *StashService) PublishContext(ctx context.Context, params StashPublishParams) (StashPublishResponse, error) {
 	var (
 		success StashPublishResponse
 		failure Error
 	)
-	_, err := s.sling.New().Post("publish").QueryStruct(&params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Post("publish").QueryStruct(&params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return StashPublishResponse{}, fmt.Errorf("unable to publish item: %w", err)
 	}
@@ -2,11 +2,11 @@ package deviantart
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"io/fs"
 	"mime/multipart"
-	"strings"
 
 	"github.com/google/go-querystring/query"
 )
@@ -67,6 +67,11 @@ type SubmitResponse struct {
 //
 //   - stash
 func (s *StashService) Submit(params *StashSubmitParams, files ...fs.File) (SubmitResponse, error) {
+	return s.SubmitContext(context.Background(), params, files...)
+}
+
+// SubmitContext is like [StashService.Submit] but uses ctx for the request.
+func (s *StashService) SubmitContext(ctx context.Context, params *StashSubmitParams, files ...fs.File) (SubmitResponse, error) {
 	var (
 		success SubmitResponse
 		failure Error
@@ -75,15 +80,17 @@ func (s *StashService) Submit(params *StashSubmitParams, files ...fs.File) (Subm
 	if err != nil {
 		return SubmitResponse{}, fmt.Errorf("prepare submit data: %w", err)
 	}
-	_, err = s.sling.New().Post("submit").BodyProvider(provider).Receive(&success, &failure)
+	_, err = receive(ctx, s.sling.New().Post("submit").BodyProvider(provider), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return SubmitResponse{}, fmt.Errorf("unable to submit file to sta.sh: %w", err)
 	}
 	return success, nil
 }
 
+// multipartBodyProvider holds an encoded request body, so it can be provided
+// again when the request is retried.
 type multipartBodyProvider struct {
-	reader      io.Reader
+	data        []byte
 	contentType string
 }
 
@@ -95,7 +102,7 @@ func newMultipartBodyProvider(params *StashSubmitParams, files ...fs.File) (*mul
 	if len(files) == 0 {
 		// Fallback to form body provider.
 		return &multipartBodyProvider{
-			reader:      strings.NewReader(values.Encode()),
+			data:        []byte(values.Encode()),
 			contentType: "application/x-www-form-urlencoded",
 		}, nil
 	}
@@ -110,15 +117,22 @@ func newMultipartBodyProvider(params *StashSubmitParams, files ...fs.File) (*mul
 		if err != nil {
 			return nil, fmt.Errorf("create from file: %w", err)
 		}
-		io.Copy(part, file)
+		if _, err := io.Copy(part, file); err != nil {
+			return nil, fmt.Errorf("copy file: %w", err)
+		}
 	}
 	for key, vals := range values {
 		for _, val := range vals {
-			mp.WriteField(key, val)
+			if err := mp.WriteField(key, val); err != nil {
+				return nil, fmt.Errorf("write field: %w", err)
+			}
 		}
 	}
+	if err := mp.Close(); err != nil {
+		return nil, fmt.Errorf("close multipart writer: %w", err)
+	}
 	return &multipartBodyProvider{
-		reader:      buf,
+		data:        buf.Bytes(),
 		contentType: mp.FormDataContentType(),
 	}, nil
 }
@@ -128,7 +142,8 @@ func (p *multipartBodyProvider) ContentType() string {
 	return p.contentType
 }
 
-// Body returns the io.Reader body.
+// Body returns the io.Reader body. Every call returns a new reader positioned
+// at the beginning of the body.
 func (p *multipartBodyProvider) Body() (io.Reader, error) {
-	return p.reader, nil
+	return bytes.NewReader(p.data), nil
 }
@@ -0,0 +1,46 @@
+package deviantart
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/leonidboykov/go-deviantart/internal/ratelimit"
+)
+
+// RateLimiter is a token bucket limiter for outgoing requests. It is safe for
+// concurrent use and may be shared between several clients to keep them under
+// a common budget.
+type RateLimiter = ratelimit.Limiter
+
+// NewRateLimiter returns a limiter that allows rate requests per second with
+// bursts of at most burst requests. Rate may be fractional, e.g. 0.5 allows a
+// request every two seconds, but must be positive; leave the limiter unset to
+// disable throttling.
+func NewRateLimiter(rate float64, burst int) (*RateLimiter, error) {
+	limiter, err := ratelimit.NewLimiter(rate, burst)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create rate limiter: %w", err)
+	}
+	return limiter, nil
+}
+
+// WithRateLimiter makes every request of the client, including retries, wait
+// for the limiter. All services of the client share the limiter.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(o *clientOptions) {
+		o.limiter = limiter
+	}
+}
+
+// WithEndpointRateLimiter makes requests to endpoints with the given path
+// prefix, such as "stash/submit" or "deviation/", wait for the limiter in
+// addition to the one set by [WithRateLimiter]. When several prefixes match,
+// the longest one is used.
+func WithEndpointRateLimiter(prefix string, limiter *RateLimiter) Option {
+	return func(o *clientOptions) {
+		if o.endpointLimiters == nil {
+			o.endpointLimiters = make(map[string]*RateLimiter)
+		}
+		o.endpointLimiters[strings.TrimPrefix(prefix, "/")] = limiter
+	}
+}
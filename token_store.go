@@ -0,0 +1,210 @@
+package deviantart
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// ErrNoToken is returned by [TokenStore] when there is no token stored.
+var ErrNoToken = errors.New("no token stored")
+
+// TokenStore persists OAuth2 tokens between runs.
+type TokenStore interface {
+	// Load returns the stored token or [ErrNoToken] if there is none.
+	Load() (*oauth2.Token, error)
+
+	// Save replaces the stored token.
+	Save(tok *oauth2.Token) error
+
+	// Clear removes the stored token.
+	Clear() error
+}
+
+// MemoryTokenStore keeps a token in memory. It is safe for concurrent use.
+type MemoryTokenStore struct {
+	mu  sync.Mutex
+	tok *oauth2.Token
+}
+
+// NewMemoryTokenStore returns an empty in-memory token store.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{}
+}
+
+func (s *MemoryTokenStore) Load() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tok == nil {
+		return nil, ErrNoToken
+	}
+	tok := *s.tok
+	return &tok, nil
+}
+
+func (s *MemoryTokenStore) Save(tok *oauth2.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := *tok
+	s.tok = &t
+	return nil
+}
+
+func (s *MemoryTokenStore) Clear() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tok = nil
+	return nil
+}
+
+// FileTokenStore keeps a token in a file readable by the owner only. It is
+// safe for concurrent use within a single process.
+type FileTokenStore struct {
+	path string
+	aead cipher.AEAD
+	mu   sync.Mutex
+}
+
+// NewFileTokenStore returns a token store backed by the file at path. If key
+// is not empty, the token is encrypted with AES-GCM; the key must be 16, 24 or
+// 32 bytes long.
+func NewFileTokenStore(path string, key []byte) (*FileTokenStore, error) {
+	s := &FileTokenStore{path: path}
+	if len(key) == 0 {
+		return s, nil
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create token cipher: %w", err)
+	}
+	if s.aead, err = cipher.NewGCM(block); err != nil {
+		return nil, fmt.Errorf("unable to create token cipher: %w", err)
+	}
+	return s, nil
+}
+
+func (s *FileTokenStore) Load() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNoToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read token: %w", err)
+	}
+	if s.aead != nil {
+		size := s.aead.NonceSize()
+		if len(data) < size {
+			return nil, errors.New("unable to decrypt token: data is too short")
+		}
+		if data, err = s.aead.Open(nil, data[:size], data[size:], nil); err != nil {
+			return nil, fmt.Errorf("unable to decrypt token: %w", err)
+		}
+	}
+	var tok oauth2.Token
+	if err := json.Unmarshal(data, &tok); err != nil {
+		return nil, fmt.Errorf("unable to decode token: %w", err)
+	}
+	return &tok, nil
+}
+
+func (s *FileTokenStore) Save(tok *oauth2.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(tok)
+	if err != nil {
+		return fmt.Errorf("unable to encode token: %w", err)
+	}
+	if s.aead != nil {
+		nonce := make([]byte, s.aead.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return fmt.Errorf("unable to encrypt token: %w", err)
+		}
+		data = s.aead.Seal(nonce, nonce, data, nil)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("unable to save token: %w", err)
+	}
+	// Write to a temporary file first, so the token is never left truncated.
+	f, err := os.CreateTemp(dir, filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("unable to save token: %w", err)
+	}
+	defer os.Remove(f.Name())
+	if err := f.Chmod(0o600); err != nil {
+		f.Close()
+		return fmt.Errorf("unable to save token: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("unable to save token: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("unable to save token: %w", err)
+	}
+	if err := os.Rename(f.Name(), s.path); err != nil {
+		return fmt.Errorf("unable to save token: %w", err)
+	}
+	return nil
+}
+
+func (s *FileTokenStore) Clear() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("unable to clear token: %w", err)
+	}
+	return nil
+}
+
+// storeTokenSource writes every new token of the underlying source to the
+// store. Failures to save the token are passed to onError and do not fail the
+// token request.
+type storeTokenSource struct {
+	base    oauth2.TokenSource
+	store   TokenStore
+	onError func(err error)
+
+	mu   sync.Mutex
+	last string
+}
+
+func newStoreTokenSource(base oauth2.TokenSource, store TokenStore, tok *oauth2.Token, onError func(err error)) *storeTokenSource {
+	return &storeTokenSource{base: base, store: store, onError: onError, last: tok.AccessToken}
+}
+
+func (s *storeTokenSource) Token() (*oauth2.Token, error) {
+	tok, err := s.base.Token()
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if tok.AccessToken == s.last {
+		return tok, nil
+	}
+	// The token is saved once, so a broken store is not hit on every request.
+	s.last = tok.AccessToken
+	if err := s.store.Save(tok); err != nil && s.onError != nil {
+		s.onError(err)
+	}
+	return tok, nil
+}
+
+func (s *storeTokenSource) unwrap() oauth2.TokenSource {
+	return s.base
+}
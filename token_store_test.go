@@ -0,0 +1,188 @@
+package deviantart
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func testToken() *oauth2.Token {
+	return &oauth2.Token{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		TokenType:    "Bearer",
+		Expiry:       time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func newFileTokenStore(t *testing.T, path string, key []byte) *FileTokenStore {
+	t.Helper()
+	store, err := NewFileTokenStore(path, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestFileTokenStoreRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		key  []byte
+	}{
+		{"plain", nil},
+		{"encrypted", bytes.Repeat([]byte{1}, 32)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "tokens", "token.json")
+			store := newFileTokenStore(t, path, tc.key)
+
+			want := testToken()
+			if err := store.Save(want); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+			got, err := store.Load()
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if got.AccessToken != want.AccessToken || got.RefreshToken != want.RefreshToken ||
+				got.TokenType != want.TokenType || !got.Expiry.Equal(want.Expiry) {
+				t.Errorf("Load = %+v, want %+v", got, want)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if encrypted := !bytes.Contains(data, []byte(want.RefreshToken)); encrypted != (tc.key != nil) {
+				t.Errorf("file content = %q, encrypted %t, want %t", data, encrypted, tc.key != nil)
+			}
+		})
+	}
+}
+
+func TestFileTokenStoreMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permissions are not supported")
+	}
+	path := filepath.Join(t.TempDir(), "token.json")
+	store := newFileTokenStore(t, path, nil)
+	if err := store.Save(testToken()); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0o600 {
+		t.Errorf("file mode = %v, want %v", mode, os.FileMode(0o600))
+	}
+}
+
+func TestFileTokenStoreCorrupted(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, 32)
+	path := filepath.Join(t.TempDir(), "token.json")
+	if err := newFileTokenStore(t, path, key).Save(testToken()); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	t.Run("wrong key", func(t *testing.T) {
+		store := newFileTokenStore(t, path, bytes.Repeat([]byte{2}, 32))
+		if tok, err := store.Load(); err == nil || errors.Is(err, ErrNoToken) {
+			t.Errorf("Load = %v, %v, want decryption error", tok, err)
+		}
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data[len(data)-1] ^= 1
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		store := newFileTokenStore(t, path, key)
+		if tok, err := store.Load(); err == nil || errors.Is(err, ErrNoToken) {
+			t.Errorf("Load = %v, %v, want decryption error", tok, err)
+		}
+	})
+}
+
+func TestFileTokenStoreMissing(t *testing.T) {
+	store := newFileTokenStore(t, filepath.Join(t.TempDir(), "token.json"), nil)
+	if _, err := store.Load(); !errors.Is(err, ErrNoToken) {
+		t.Errorf("Load error = %v, want %v", err, ErrNoToken)
+	}
+	if err := store.Clear(); err != nil {
+		t.Errorf("Clear of missing token: %v", err)
+	}
+}
+
+func TestStoreTokenSourceSavesRefreshedToken(t *testing.T) {
+	srv := newOAuthServer(t)
+	conf := srv.config(false).oauth2Config()
+	expired := &oauth2.Token{
+		AccessToken:  "expired",
+		RefreshToken: "old",
+		TokenType:    "Bearer",
+		Expiry:       time.Now().Add(-time.Hour),
+	}
+	store := NewMemoryTokenStore()
+	store.Save(expired)
+
+	ts := newStoreTokenSource(configTokenSource(context.Background(), conf, expired), store, expired, func(err error) {
+		t.Errorf("save error: %v", err)
+	})
+	for range 2 {
+		if _, err := ts.Token(); err != nil {
+			t.Fatalf("Token: %v", err)
+		}
+	}
+
+	tok, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if tok.AccessToken != "access" || tok.RefreshToken != "refresh" {
+		t.Errorf("stored token = %+v, want the refreshed one", tok)
+	}
+	forms := srv.tokenRequests()
+	if len(forms) != 1 || forms[0].Get("refresh_token") != "old" {
+		t.Errorf("token requests = %v, want one refresh with the stored token", forms)
+	}
+}
+
+// failingTokenStore fails to save tokens.
+type failingTokenStore struct {
+	TokenStore
+	saves int
+}
+
+func (s *failingTokenStore) Save(*oauth2.Token) error {
+	s.saves++
+	return errors.New("disk is full")
+}
+
+func TestStoreTokenSourceSaveError(t *testing.T) {
+	store := &failingTokenStore{TokenStore: NewMemoryTokenStore()}
+	var errs []error
+	base := oauth2.StaticTokenSource(testToken())
+	ts := newStoreTokenSource(base, store, &oauth2.Token{AccessToken: "previous"}, func(err error) {
+		errs = append(errs, err)
+	})
+	for range 2 {
+		if _, err := ts.Token(); err != nil {
+			t.Fatalf("Token: %v, want the token despite the failing store", err)
+		}
+	}
+	if store.saves != 1 || len(errs) != 1 {
+		t.Errorf("saves = %d, reported errors = %v, want a single failed save", store.saves, errs)
+	}
+}
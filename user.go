@@ -1,7 +1,9 @@
 package deviantart
 
 import (
+	"context"
 	"fmt"
+	"iter"
 
 	"github.com/dghubble/sling"
 	"github.com/google/uuid"
@@ -70,11 +72,16 @@ func newUserService(sling *sling.Sling) *UserService {
 //
 //   - user
 func (s *UserService) DAmnToken() (string, error) {
+	return s.DAmnTokenContext(context.Background())
+}
+
+// DAmnTokenContext is like [UserService.DAmnToken] but uses ctx for the request.
+func (s *UserService) DAmnTokenContext(ctx context.Context) (string, error) {
 	var (
 		success map[string]any
 		failure Error
 	)
-	_, err := s.sling.New().Get("damntoken").Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("damntoken"), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return "", fmt.Errorf("unable to fetch dAmn token: %w", err)
 	}
@@ -92,11 +99,16 @@ func (s *UserService) DAmnToken() (string, error) {
 //
 // TODO: Scope is missing in docs.
 func (s *UserService) Tiers(username string) ([]Deviation, error) {
+	return s.TiersContext(context.Background(), username)
+}
+
+// TiersContext is like [UserService.Tiers] but uses ctx for the request.
+func (s *UserService) TiersContext(ctx context.Context, username string) ([]Deviation, error) {
 	var (
 		success singleResponse[Deviation]
 		failure Error
 	)
-	_, err := s.sling.New().Get("tiers/").Path(username).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("tiers/").Path(username), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return nil, fmt.Errorf("unable to user tiers: %w", err)
 	}
@@ -112,17 +124,29 @@ func (s *UserService) Tiers(username string) ([]Deviation, error) {
 //
 //   - browse
 func (s *UserService) Watchers(username string, page *OffsetParams) (OffsetResponse[Friend], error) {
+	return s.WatchersContext(context.Background(), username, page)
+}
+
+// WatchersContext is like [UserService.Watchers] but uses ctx for the request.
+func (s *UserService) WatchersContext(ctx context.Context, username string, page *OffsetParams) (OffsetResponse[Friend], error) {
 	var (
 		success OffsetResponse[Friend]
 		failure Error
 	)
-	_, err := s.sling.New().Get("watchers/").Path(username).QueryStruct(page).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("watchers/").Path(username).QueryStruct(page), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return OffsetResponse[Friend]{}, fmt.Errorf("unable to get user watchers: %w", err)
 	}
 	return success, nil
 }
 
+// WatchersIter iterates over all pages of [UserService.Watchers].
+func (s *UserService) WatchersIter(ctx context.Context, username string, opts *IterOptions) iter.Seq2[Friend, error] {
+	return iterOffset(ctx, opts, iterOrigin("User.Watchers", username), func(ctx context.Context, page *OffsetParams) (OffsetResponse[Friend], error) {
+		return s.WatchersContext(ctx, username, page)
+	})
+}
+
 // Whoami fetches user info of authenticated user.
 //
 // To connect to this endpoint OAuth2 Access Token from the Authorization Code
@@ -132,11 +156,16 @@ func (s *UserService) Watchers(username string, page *OffsetParams) (OffsetRespo
 //
 //   - user
 func (s *UserService) Whoami() (User, error) {
+	return s.WhoamiContext(context.Background())
+}
+
+// WhoamiContext is like [UserService.Whoami] but uses ctx for the request.
+func (s *UserService) WhoamiContext(ctx context.Context) (User, error) {
 	var (
 		success User
 		failure Error
 	)
-	_, err := s.sling.New().Get("whoami").Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("whoami"), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return User{}, fmt.Errorf("unable to fetch whoami: %w", err)
 	}
@@ -152,6 +181,11 @@ func (s *UserService) Whoami() (User, error) {
 //
 //   - browse
 func (s *UserService) Whois(usernames ...string) ([]User, error) {
+	return s.WhoisContext(context.Background(), usernames...)
+}
+
+// WhoisContext is like [UserService.Whois] but uses ctx for the request.
+func (s *UserService) WhoisContext(ctx context.Context, usernames ...string) ([]User, error) {
 	type usernameParams struct {
 		Usernames []string `url:"usernames"` // TODO: Implement square brackets with number inside.
 	}
@@ -160,7 +194,7 @@ func (s *UserService) Whois(usernames ...string) ([]User, error) {
 		failure Error
 	)
 	params := &usernameParams{Usernames: usernames}
-	_, err := s.sling.New().Post("whois/").BodyForm(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Post("whois/").BodyForm(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return nil, fmt.Errorf("unable to fetch whois: %w", err)
 	}
@@ -1,7 +1,9 @@
 package deviantart
 
 import (
+	"context"
 	"fmt"
+	"iter"
 
 	"github.com/dghubble/sling"
 )
@@ -44,17 +46,29 @@ type UserWatch struct {
 //
 //   - browse
 func (s *friendsService) Get(username string, page *OffsetParams) (OffsetResponse[Friend], error) {
+	return s.GetContext(context.Background(), username, page)
+}
+
+// GetContext is like [friendsService.Get] but uses ctx for the request.
+func (s *friendsService) GetContext(ctx context.Context, username string, page *OffsetParams) (OffsetResponse[Friend], error) {
 	var (
 		success OffsetResponse[Friend]
 		failure Error
 	)
-	_, err := s.sling.New().Get(username).QueryStruct(page).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get(username).QueryStruct(page), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return OffsetResponse[Friend]{}, fmt.Errorf("unable to get friends: %w", err)
 	}
 	return success, nil
 }
 
+// GetIter iterates over all pages of [friendsService.Get].
+func (s *friendsService) GetIter(ctx context.Context, username string, opts *IterOptions) iter.Seq2[Friend, error] {
+	return iterOffset(ctx, opts, iterOrigin("User.Friends.Get", username), func(ctx context.Context, page *OffsetParams) (OffsetResponse[Friend], error) {
+		return s.GetContext(ctx, username, page)
+	})
+}
+
 type FriendsSearchParams struct {
 	Username string `url:"username,omitempty"`
 	Search   string `url:"search,omitempty"`
@@ -70,11 +84,16 @@ type FriendsSearchParams struct {
 //
 //   - browse
 func (s *friendsService) Search(params *FriendsSearchParams) ([]User, error) {
+	return s.SearchContext(context.Background(), params)
+}
+
+// SearchContext is like [friendsService.Search] but uses ctx for the request.
+func (s *friendsService) SearchContext(ctx context.Context, params *FriendsSearchParams) ([]User, error) {
 	var (
 		success singleResponse[User]
 		failure Error
 	)
-	_, err := s.sling.New().Get("search").QueryStruct(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("search").QueryStruct(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return nil, fmt.Errorf("unable to search friends: %w", err)
 	}
@@ -90,6 +109,11 @@ func (s *friendsService) Search(params *FriendsSearchParams) ([]User, error) {
 //
 // TODO: Make better comments for access types and scopes (make it in a single paragraph/sentence).
 func (s *friendsService) Watch(username string, params *UserWatch) (bool, error) {
+	return s.WatchContext(context.Background(), username, params)
+}
+
+// WatchContext is like [friendsService.Watch] but uses ctx for the request.
+func (s *friendsService) WatchContext(ctx context.Context, username string, params *UserWatch) (bool, error) {
 	type watch struct {
 		Watch UserWatch `url:"watch"`
 	}
@@ -97,7 +121,7 @@ func (s *friendsService) Watch(username string, params *UserWatch) (bool, error)
 		success map[string]any
 		failure Error
 	)
-	_, err := s.sling.New().Post("watch/").Path(username).BodyForm(&watch{Watch: *params}).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Post("watch/").Path(username).BodyForm(&watch{Watch: *params}), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return false, fmt.Errorf("unable to watch a user: %w", err)
 	}
@@ -113,11 +137,16 @@ func (s *friendsService) Watch(username string, params *UserWatch) (bool, error)
 //
 // TODO: Make better comments for access types and scopes (make it in a single paragraph/sentence).
 func (s *friendsService) Unwatch(username string) (bool, error) {
+	return s.UnwatchContext(context.Background(), username)
+}
+
+// UnwatchContext is like [friendsService.Unwatch] but uses ctx for the request.
+func (s *friendsService) UnwatchContext(ctx context.Context, username string) (bool, error) {
 	var (
 		success map[string]any
 		failure Error
 	)
-	_, err := s.sling.New().Get("unwatch/").Path(username).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("unwatch/").Path(username), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return false, fmt.Errorf("unable to unwatch a user: %w", err)
 	}
@@ -133,11 +162,16 @@ func (s *friendsService) Unwatch(username string) (bool, error) {
 //
 // TODO: Make better comments for access types and scopes (make it in a single paragraph/sentence).
 func (s *friendsService) Watching(username string) (bool, error) {
+	return s.WatchingContext(context.Background(), username)
+}
+
+// WatchingContext is like [friendsService.Watching] but uses ctx for the request.
+func (s *friendsService) WatchingContext(ctx context.Context, username string) (bool, error) {
 	var (
 		success map[string]any
 		failure Error
 	)
-	_, err := s.sling.New().Get("watching/").Path(username).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("watching/").Path(username), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return false, fmt.Errorf("unable to check watching for a user: %w", err)
 	}
@@ -1,7 +1,9 @@
 package deviantart
 
 import (
+	"context"
 	"fmt"
+	"iter"
 
 	"github.com/google/uuid"
 )
@@ -65,11 +67,16 @@ type GetProfileParams struct {
 //
 //   - browse
 func (s *UserService) Profile(username string, params *GetProfileParams) (Profile, error) {
+	return s.ProfileContext(context.Background(), username, params)
+}
+
+// ProfileContext is like [UserService.Profile] but uses ctx for the request.
+func (s *UserService) ProfileContext(ctx context.Context, username string, params *GetProfileParams) (Profile, error) {
 	var (
 		success Profile
 		failure Error
 	)
-	_, err := s.sling.New().Post("profile/").Path(username).QueryStruct(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Post("profile/").Path(username).QueryStruct(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return Profile{}, fmt.Errorf("unable to fetch profile: %w", err)
 	}
@@ -85,18 +92,30 @@ func (s *UserService) Profile(username string, params *GetProfileParams) (Profil
 //
 //   - browse
 func (s *UserService) Posts(username string, page *CursorParams) (CursorResponse[Deviation], error) {
+	return s.PostsContext(context.Background(), username, page)
+}
+
+// PostsContext is like [UserService.Posts] but uses ctx for the request.
+func (s *UserService) PostsContext(ctx context.Context, username string, page *CursorParams) (CursorResponse[Deviation], error) {
 	var (
 		success CursorResponse[Deviation]
 		failure Error
 	)
 	params := &usernameParams{Username: username}
-	_, err := s.sling.New().Get("profile/posts").QueryStruct(params).QueryStruct(page).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("profile/posts").QueryStruct(params).QueryStruct(page), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return CursorResponse[Deviation]{}, fmt.Errorf("unable to fetch profile: %w", err)
 	}
 	return success, nil
 }
 
+// PostsIter iterates over all pages of [UserService.Posts].
+func (s *UserService) PostsIter(ctx context.Context, username string, opts *IterOptions) iter.Seq2[Deviation, error] {
+	return iterCursor(ctx, opts, iterOrigin("User.Posts", username), func(ctx context.Context, page *CursorParams) (CursorResponse[Deviation], error) {
+		return s.PostsContext(ctx, username, page)
+	})
+}
+
 const (
 	ArtistLevelNone         = "None"
 	ArtistLevelStudent      = "Student"
@@ -145,12 +164,24 @@ type UserInfoParams struct {
 // UpdateProfile updates the users profile information.
 //
 // Check [Countries] to get a list of countries and their IDs.
+//
+// To connect to this endpoint OAuth2 Access Token from the Authorization Code
+// Grant is required.
+//
+// The following scopes are required to access this resource:
+//
+//   - user.manage
 func (s *UserService) UpdateProfile(params *UserInfoParams) (bool, error) {
+	return s.UpdateProfileContext(context.Background(), params)
+}
+
+// UpdateProfileContext is like [UserService.UpdateProfile] but uses ctx for the request.
+func (s *UserService) UpdateProfileContext(ctx context.Context, params *UserInfoParams) (bool, error) {
 	var (
 		success map[string]any
 		failure Error
 	)
-	_, err := s.sling.New().Post("profile/update").BodyForm(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Post("profile/update").BodyForm(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return false, fmt.Errorf("unable to update user profile: %w", err)
 	}
@@ -1,7 +1,9 @@
 package deviantart
 
 import (
+	"context"
 	"fmt"
+	"iter"
 
 	"github.com/google/uuid"
 )
@@ -39,11 +41,16 @@ type Status struct {
 //
 //   - browse
 func (s *UserService) Status(statusID uuid.UUID) (Status, error) {
+	return s.StatusContext(context.Background(), statusID)
+}
+
+// StatusContext is like [UserService.Status] but uses ctx for the request.
+func (s *UserService) StatusContext(ctx context.Context, statusID uuid.UUID) (Status, error) {
 	var (
 		success Status
 		failure Error
 	)
-	_, err := s.sling.New().Get("statuses/").Path(statusID.String()).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("statuses/").Path(statusID.String()), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return Status{}, fmt.Errorf("unable to fetch status: %w", err)
 	}
@@ -59,18 +66,30 @@ func (s *UserService) Status(statusID uuid.UUID) (Status, error) {
 //
 //   - browse
 func (s *UserService) Statuses(username string, page *OffsetParams) (OffsetResponse[Status], error) {
+	return s.StatusesContext(context.Background(), username, page)
+}
+
+// StatusesContext is like [UserService.Statuses] but uses ctx for the request.
+func (s *UserService) StatusesContext(ctx context.Context, username string, page *OffsetParams) (OffsetResponse[Status], error) {
 	var (
 		success OffsetResponse[Status]
 		failure Error
 	)
 	params := &usernameParams{Username: username}
-	_, err := s.sling.New().Get("statuses").QueryStruct(params).QueryStruct(page).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Get("statuses").QueryStruct(params).QueryStruct(page), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return OffsetResponse[Status]{}, fmt.Errorf("unable to fetch user statuses: %w", err)
 	}
 	return success, nil
 }
 
+// StatusesIter iterates over all pages of [UserService.Statuses].
+func (s *UserService) StatusesIter(ctx context.Context, username string, opts *IterOptions) iter.Seq2[Status, error] {
+	return iterOffset(ctx, opts, iterOrigin("User.Statuses", username), func(ctx context.Context, page *OffsetParams) (OffsetResponse[Status], error) {
+		return s.StatusesContext(ctx, username, page)
+	})
+}
+
 type PostStatusParams struct {
 	// The body of the status.
 	Text string `url:"body,omitempty"`
@@ -98,6 +117,11 @@ type PostStatusParams struct {
 //   - browse
 //   - user.manage
 func (s *UserService) PostStatus(params *PostStatusParams) (uuid.UUID, error) {
+	return s.PostStatusContext(context.Background(), params)
+}
+
+// PostStatusContext is like [UserService.PostStatus] but uses ctx for the request.
+func (s *UserService) PostStatusContext(ctx context.Context, params *PostStatusParams) (uuid.UUID, error) {
 	type response struct {
 		StatusID uuid.UUID `json:"statusid"`
 	}
@@ -105,7 +129,7 @@ func (s *UserService) PostStatus(params *PostStatusParams) (uuid.UUID, error) {
 		success response
 		failure Error
 	)
-	_, err := s.sling.New().Post("statuses/post").BodyForm(params).Receive(&success, &failure)
+	_, err := receive(ctx, s.sling.New().Post("statuses/post").BodyForm(params), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return uuid.Nil, fmt.Errorf("unable to post status: %w", err)
 	}
@@ -1,14 +1,22 @@
 package deviantart
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // Placebo call confirms access_token is valid.
 func (c *Client) Placebo() error {
+	return c.PlaceboContext(context.Background())
+}
+
+// PlaceboContext is like [Client.Placebo] but uses ctx for the request.
+func (c *Client) PlaceboContext(ctx context.Context) error {
 	var (
 		success StatusResponse
 		failure Error
 	)
-	_, err := c.base.New().Get("placebo").Receive(&success, &failure)
+	_, err := receive(ctx, c.base.New().Get("placebo"), &success, &failure)
 	if err := relevantError(err, failure); err != nil {
 		return fmt.Errorf("unable to validate access_token: %w", err)
 	}